@@ -0,0 +1,349 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// tinyPath is the import path of the tiny package.
+const tinyPath = "github.com/xxlv/go-tinylib/pkg/tiny"
+
+// config describes one adapter to generate.
+type config struct {
+	Type    string // The interface to adapt.
+	Name    string // The adapter type; defaults to Type+"Result", or Type+"Unwrapped" when Reverse is set.
+	Reverse bool   // Adapt an interface of Result-returning methods back to (T, E) methods.
+}
+
+// method is an interface method, with the parameter names the adapter uses.
+type method struct {
+	name     string
+	params   []param
+	results  []ast.Expr
+	variadic bool
+}
+
+// param is a named method parameter.
+type param struct {
+	name string
+	typ  ast.Expr
+}
+
+// reserved are the identifiers used by the generated method bodies, which parameters must not shadow.
+var reserved = map[string]bool{"a": true, "r": true, "zero": true, "tiny": true}
+
+// generate parses the Go file src and returns the formatted source of the adapter described by cfg,
+// in the same package as src.
+func generate(filename string, src []byte, cfg config) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	iface, err := findInterface(file, cfg.Type)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := interfaceMethods(iface, cfg.Type)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Name == "" {
+		cfg.Name = cfg.Type + "Result"
+		if cfg.Reverse {
+			cfg.Name = cfg.Type + "Unwrapped"
+		}
+	}
+
+	imports := fileImports(file)
+	tinyName := "tiny"
+	for name, path := range imports {
+		if path == tinyPath {
+			tinyName = name
+		}
+	}
+	g := generator{cfg: cfg, tiny: tinyName, used: map[string]bool{}}
+	for _, m := range methods {
+		if cfg.Reverse {
+			g.reverseMethod(m)
+		} else {
+			g.forwardMethod(m)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by tinygen; DO NOT EDIT.\n\npackage %s\n\n", file.Name.Name)
+	out.WriteString("import (\n")
+	std := true
+	for _, name := range g.imports(imports) {
+		path := importPath(imports, name, g.tiny)
+		if std && !isStd(path) {
+			std = false
+			out.WriteString("\n")
+		}
+		if name == defaultName(path) {
+			fmt.Fprintf(&out, "\t%s\n", strconv.Quote(path))
+		} else {
+			fmt.Fprintf(&out, "\t%s %s\n", name, strconv.Quote(path))
+		}
+	}
+	out.WriteString(")\n\n")
+	if cfg.Reverse {
+		fmt.Fprintf(&out, "// %s adapts a %s, whose methods return tiny.Result values, to methods returning a value and an error.\n", cfg.Name, cfg.Type)
+	} else {
+		fmt.Fprintf(&out, "// %s adapts a %s to methods returning tiny.Result values.\n", cfg.Name, cfg.Type)
+	}
+	fmt.Fprintf(&out, "type %s struct {\n\tinner %s\n}\n\n", cfg.Name, cfg.Type)
+	fmt.Fprintf(&out, "// New%s returns a %s that calls inner.\n", cfg.Name, cfg.Name)
+	fmt.Fprintf(&out, "func New%s(inner %s) *%s {\n\treturn &%s{inner: inner}\n}\n", cfg.Name, cfg.Type, cfg.Name, cfg.Name)
+	out.Write(g.body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// findInterface returns the interface declared as name in file.
+func findInterface(file *ast.File, name string) (*ast.InterfaceType, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an interface", name)
+			}
+			if ts.TypeParams != nil {
+				return nil, fmt.Errorf("%s: generic interfaces are not supported", name)
+			}
+			return iface, nil
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found", name)
+}
+
+// interfaceMethods returns the methods of iface, naming unnamed parameters and renaming those that clash with the generated code.
+func interfaceMethods(iface *ast.InterfaceType, name string) ([]method, error) {
+	var methods []method
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, fmt.Errorf("%s: embedded interface %s is not supported; list its methods instead", name, types.ExprString(field.Type))
+		}
+		m := method{name: field.Names[0].Name}
+		for _, p := range fn.Params.List {
+			typ := p.Type
+			if ell, ok := typ.(*ast.Ellipsis); ok {
+				m.variadic = true
+				typ = ell
+			}
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{{Name: "_"}}
+			}
+			for _, n := range names {
+				pname := n.Name
+				if pname == "_" {
+					pname = fmt.Sprintf("p%d", len(m.params))
+				}
+				for reserved[pname] {
+					pname += "_"
+				}
+				m.params = append(m.params, param{name: pname, typ: typ})
+			}
+		}
+		if fn.Results != nil {
+			for _, r := range fn.Results.List {
+				for range max(len(r.Names), 1) {
+					m.results = append(m.results, r.Type)
+				}
+			}
+		}
+		methods = append(methods, m)
+	}
+	return methods, nil
+}
+
+// fileImports maps the names under which file imports packages to their paths.
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := defaultName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// defaultName guesses the package name of path from its last element.
+func defaultName(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// isStd reports whether path belongs to the standard library, whose import paths have no dot in their first element.
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// generator accumulates the methods of an adapter and the packages they refer to.
+type generator struct {
+	cfg  config
+	tiny string
+	body bytes.Buffer
+	used map[string]bool
+}
+
+// expr returns the source of a type expression, recording the packages it refers to.
+func (g *generator) expr(e ast.Expr) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				g.used[id.Name] = true
+			}
+			return false
+		}
+		return true
+	})
+	return types.ExprString(e)
+}
+
+// imports returns the sorted names of the packages the generated code needs.
+func (g *generator) imports(available map[string]string) []string {
+	var names []string
+	for name := range g.used {
+		if _, ok := available[name]; ok || name == g.tiny {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := importPath(available, names[i], g.tiny), importPath(available, names[j], g.tiny)
+		if isStd(pi) != isStd(pj) {
+			return isStd(pi)
+		}
+		return pi < pj
+	})
+	return names
+}
+
+// importPath returns the path imported as name, which for the tiny package may not be imported by the source yet.
+func importPath(available map[string]string, name, tiny string) string {
+	if name == tiny && available[name] == "" {
+		return tinyPath
+	}
+	return available[name]
+}
+
+// signature returns the parameter list of m and the argument list that forwards it.
+func (g *generator) signature(m method) (params, args string) {
+	var ps, as []string
+	for i, p := range m.params {
+		typ := g.expr(p.typ)
+		if m.variadic && i == len(m.params)-1 {
+			typ = "..." + g.expr(p.typ.(*ast.Ellipsis).Elt)
+			as = append(as, p.name+"...")
+		} else {
+			as = append(as, p.name)
+		}
+		ps = append(ps, p.name+" "+typ)
+	}
+	return strings.Join(ps, ", "), strings.Join(as, ", ")
+}
+
+// passThrough writes a method that forwards m unchanged, for methods that do not fit the adapted shape.
+func (g *generator) passThrough(m method, params, args string) {
+	var results []string
+	for _, r := range m.results {
+		results = append(results, g.expr(r))
+	}
+	fmt.Fprintf(&g.body, "\n// %s calls %s on the wrapped %s.\n", m.name, m.name, g.cfg.Type)
+	fmt.Fprintf(&g.body, "func (a *%s) %s(%s) (%s) {\n", g.cfg.Name, m.name, params, strings.Join(results, ", "))
+	if len(results) > 0 {
+		fmt.Fprintf(&g.body, "\treturn a.inner.%s(%s)\n}\n", m.name, args)
+	} else {
+		fmt.Fprintf(&g.body, "\ta.inner.%s(%s)\n}\n", m.name, args)
+	}
+}
+
+// forwardMethod writes a method returning a Result for a method returning (T, error) or error,
+// built with tiny.From so that a typed nil error counts as no error. Other methods are passed through unchanged.
+func (g *generator) forwardMethod(m method) {
+	params, args := g.signature(m)
+	last := len(m.results) - 1
+	if last < 0 || last > 1 || !isIdent(m.results[last], "error") {
+		g.passThrough(m, params, args)
+		return
+	}
+	g.used[g.tiny] = true
+	fmt.Fprintf(&g.body, "\n// %s calls %s on the wrapped %s and returns its outcome as a Result.\n", m.name, m.name, g.cfg.Type)
+	if last == 0 {
+		fmt.Fprintf(&g.body, "func (a *%s) %s(%s) %s.Result[struct{}, error] {\n", g.cfg.Name, m.name, params, g.tiny)
+		fmt.Fprintf(&g.body, "\treturn %s.From(struct{}{}, a.inner.%s(%s))\n}\n", g.tiny, m.name, args)
+		return
+	}
+	fmt.Fprintf(&g.body, "func (a *%s) %s(%s) %s.Result[%s, error] {\n", g.cfg.Name, m.name, params, g.tiny, g.expr(m.results[0]))
+	fmt.Fprintf(&g.body, "\treturn %s.From(a.inner.%s(%s))\n}\n", g.tiny, m.name, args)
+}
+
+// reverseMethod writes a method returning (T, E) for a method returning tiny.Result[T, E],
+// or only E when T is struct{}. Other methods are passed through unchanged.
+func (g *generator) reverseMethod(m method) {
+	params, args := g.signature(m)
+	var valueType, errType ast.Expr
+	if len(m.results) == 1 {
+		valueType, errType = g.resultTypes(m.results[0])
+	}
+	if valueType == nil {
+		g.passThrough(m, params, args)
+		return
+	}
+	errStr := g.expr(errType)
+	fmt.Fprintf(&g.body, "\n// %s calls %s on the wrapped %s and returns the value and error of its Result.\n", m.name, m.name, g.cfg.Type)
+	if st, ok := valueType.(*ast.StructType); ok && len(st.Fields.List) == 0 {
+		fmt.Fprintf(&g.body, "func (a *%s) %s(%s) %s {\n", g.cfg.Name, m.name, params, errStr)
+		fmt.Fprintf(&g.body, "\treturn a.inner.%s(%s).Unwrap()\n}\n", m.name, args)
+		return
+	}
+	typ := g.expr(valueType)
+	fmt.Fprintf(&g.body, "func (a *%s) %s(%s) (%s, %s) {\n", g.cfg.Name, m.name, params, typ, errStr)
+	fmt.Fprintf(&g.body, "\tr := a.inner.%s(%s)\n", m.name, args)
+	fmt.Fprintf(&g.body, "\tvar zero %s\n\treturn r.OrElse(zero), r.Unwrap()\n}\n", typ)
+}
+
+// resultTypes returns the type arguments of e if it is tiny.Result[T, E], or nils otherwise.
+func (g *generator) resultTypes(e ast.Expr) (value, err ast.Expr) {
+	idx, ok := e.(*ast.IndexListExpr)
+	if !ok || len(idx.Indices) != 2 {
+		return nil, nil
+	}
+	sel, ok := idx.X.(*ast.SelectorExpr)
+	if !ok || !isIdent(sel.X, g.tiny) || sel.Sel.Name != "Result" {
+		return nil, nil
+	}
+	return idx.Indices[0], idx.Indices[1]
+}
+
+// isIdent reports whether e is the identifier name.
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestGenerateGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    config
+		golden string
+	}{
+		{name: "forward", cfg: config{Type: "UserRepo"}, golden: "userrepo.golden"},
+		{name: "reverse", cfg: config{Type: "ResultRepo", Reverse: true}, golden: "resultrepo.golden"},
+		{name: "custom name", cfg: config{Type: "ResultRepo", Name: "PlainRepo", Reverse: true}, golden: "plainrepo.golden"},
+	}
+
+	src, err := os.ReadFile("testdata/repo.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generate("repo.go", src, tt.cfg)
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("generate() differs from %s; run go test -update and review the diff.\ngot:\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		typ     string
+		wantErr string
+	}{
+		{typ: "Missing", wantErr: "not found"},
+		{typ: "NotAnInterface", wantErr: "not an interface"},
+		{typ: "Embedding", wantErr: "embedded interface io.Closer"},
+	}
+
+	src, err := os.ReadFile("testdata/repo.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			_, err := generate("repo.go", src, config{Type: tt.typ})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("generate() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.go")
+	if err := run("testdata/repo.go", output, config{Type: "UserRepo"}); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("testdata/userrepo.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("run() wrote:\n%s", got)
+	}
+}
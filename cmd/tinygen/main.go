@@ -0,0 +1,74 @@
+// Command tinygen generates adapters between interfaces whose methods return (T, error)
+// and types whose methods return tiny.Result[T, error].
+//
+// Given an interface such as
+//
+//	type UserRepo interface {
+//	    Find(ctx context.Context, id int) (*User, error)
+//	    Delete(ctx context.Context, id int) error
+//	}
+//
+// tinygen -type=UserRepo generates a UserRepoResult type, created with NewUserRepoResult(repo),
+// whose Find returns tiny.Result[*User, error] and whose Delete returns tiny.Result[struct{}, error].
+// Methods of any other shape are passed through unchanged.
+//
+// With -reverse, the interface's methods return tiny.Result[T, E] instead, and the generated
+// UserRepoUnwrapped type returns (T, E), or only E for tiny.Result[struct{}, E].
+//
+// tinygen is meant to be run by go generate, next to the interface:
+//
+//	//go:generate go run github.com/xxlv/go-tinylib/cmd/tinygen -type=UserRepo
+//
+// The input file defaults to $GOFILE, and the output to <type>_tiny.go in the same directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.Type, "type", "", "name of the interface to adapt (required)")
+	flag.StringVar(&cfg.Name, "name", "", "name of the generated type (default <type>Result, or <type>Unwrapped with -reverse)")
+	flag.BoolVar(&cfg.Reverse, "reverse", false, "adapt an interface returning tiny.Result values to (T, E) methods")
+	output := flag.String("output", "", "output file (default <type>_tiny.go next to the input)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: tinygen -type=Name [flags] [file.go]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	input := os.Getenv("GOFILE")
+	if flag.NArg() > 0 {
+		input = flag.Arg(0)
+	}
+	if cfg.Type == "" || input == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = filepath.Join(filepath.Dir(input), strings.ToLower(cfg.Type)+"_tiny.go")
+	}
+
+	if err := run(input, *output, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "tinygen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the adapter for the interface in input and writes it to output.
+func run(input, output string, cfg config) error {
+	src, err := os.ReadFile(input)
+	if err != nil {
+		return err
+	}
+	code, err := generate(input, src, cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, code, 0o644)
+}
@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"context"
+	"io"
+	stdtime "time"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// UserRepo stores users.
+type UserRepo interface {
+	Find(ctx context.Context, id int) (*User, error)
+	List(context.Context, ...int) ([]User, error)
+	Since(ctx context.Context, t stdtime.Time) (map[int]User, error)
+	Delete(ctx context.Context, id int) error
+	Export(w io.Writer, err string) (n int, e error)
+	Len() int
+	Close()
+}
+
+// ResultRepo is a UserRepo written against tiny.
+type ResultRepo interface {
+	Find(ctx context.Context, id int) tiny.Result[*User, error]
+	Delete(ctx context.Context, id int) tiny.Result[struct{}, error]
+	Len() int
+}
+
+type NotAnInterface struct{}
+
+type Embedding interface {
+	io.Closer
+}
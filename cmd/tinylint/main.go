@@ -0,0 +1,17 @@
+// Command tinylint reports common misuses of tiny.Result.
+//
+// Run it on packages directly, or as a vet tool:
+//
+//	go run github.com/xxlv/go-tinylib/cmd/tinylint ./...
+//	go vet -vettool=$(which tinylint) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/xxlv/go-tinylib/pkg/tinylint"
+)
+
+func main() {
+	multichecker.Main(tinylint.Analyzers...)
+}
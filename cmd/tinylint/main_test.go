@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStandalone builds tinylint and runs it directly on a package, as `go run .../cmd/tinylint ./...` does.
+func TestStandalone(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs the command")
+	}
+	bin := filepath.Join(t.TempDir(), "tinylint")
+	if out, err := exec.Command("go", "build", "-o", bin, ".").CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	out, err := exec.Command(bin, "./testdata/misuse").CombinedOutput()
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) || exit.ExitCode() != 3 {
+		t.Fatalf("tinylint exited with %v, want exit status 3 for diagnostics\n%s", err, out)
+	}
+	for _, want := range []string{
+		"misuse.go:9:2: result of double is never inspected",
+		"misuse.go:10:2: channel returned by AsyncThen is never received from",
+		"misuse.go:11:19: UnwrapOrPanic outside tests and package main",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("tinylint output lacks %q:\n%s", want, out)
+		}
+	}
+}
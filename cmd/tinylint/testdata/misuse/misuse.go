@@ -0,0 +1,12 @@
+// Package misuse holds one misuse for each tinylint analyzer, for the tests of the command.
+package misuse
+
+import "github.com/xxlv/go-tinylib/pkg/tiny"
+
+func double(v int) tiny.Result[int, error] { return tiny.Ok[int, error](v * 2) }
+
+func misuse() int {
+	double(1)
+	tiny.AsyncThen(double(1), double)
+	return double(2).UnwrapOrPanic()
+}
@@ -0,0 +1,51 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// errorAdapters holds the adapters registered with RegisterErrorAdapter, keyed by the reflect.Type of E.
+var errorAdapters sync.Map
+
+// RegisterErrorAdapter registers fn to convert errors raised by the library itself, such as context cancellation
+// and timeouts, into the error type E of a Result.
+// Without an adapter, such errors can only be delivered to Results whose E they already satisfy, like plain error.
+// Registering a second adapter for the same E replaces the first.
+//
+// Example:
+//
+//	RegisterErrorAdapter(func(err error) *AppError {
+//	    return &AppError{Code: "internal", Cause: err}
+//	})
+func RegisterErrorAdapter[E error](fn func(error) E) {
+	errorAdapters.Store(reflect.TypeFor[E](), fn)
+}
+
+// AdaptError converts err into E.
+// It returns err itself if it already is an E, then tries the adapter registered for E,
+// and finally looks for an E in err's chain with errors.As.
+// It panics if none of these apply, naming the adapter that is missing.
+func AdaptError[E error](err error) E {
+	if e, ok := tryAdaptError[E](err); ok {
+		return e
+	}
+	panic(fmt.Sprintf("tiny: cannot convert %T to %v; register one with RegisterErrorAdapter", err, reflect.TypeFor[E]()))
+}
+
+// tryAdaptError is AdaptError reporting false instead of panicking when err cannot be converted.
+func tryAdaptError[E error](err error) (E, bool) {
+	if e, ok := err.(E); ok {
+		return e, true
+	}
+	if fn, ok := errorAdapters.Load(reflect.TypeFor[E]()); ok {
+		return fn.(func(error) E)(err), true
+	}
+	var target E
+	if errors.As(err, &target) {
+		return target, true
+	}
+	return target, false
+}
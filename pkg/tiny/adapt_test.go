@@ -0,0 +1,52 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type adaptTestError struct {
+	cause error
+}
+
+func (e *adaptTestError) Error() string { return "adapted: " + e.cause.Error() }
+func (e *adaptTestError) Unwrap() error { return e.cause }
+
+type unregisteredTestError struct{}
+
+func (unregisteredTestError) Error() string { return "unregistered" }
+
+func TestAdaptError(t *testing.T) {
+	RegisterErrorAdapter(func(err error) *adaptTestError { return &adaptTestError{cause: err} })
+
+	if got := AdaptError[error](context.Canceled); got != context.Canceled {
+		t.Errorf("AdaptError[error] should return err unchanged, got %v", got)
+	}
+
+	own := &adaptTestError{cause: errors.New("own")}
+	if got := AdaptError[*adaptTestError](own); got != own {
+		t.Errorf("AdaptError should return an E unchanged, got %v", got)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := ThenWithContext(canceled, Ok[int, *adaptTestError](1), func(v int) Result[int, *adaptTestError] {
+		return Ok[int, *adaptTestError](v)
+	})
+	if !errors.Is(r.Unwrap(), context.Canceled) {
+		t.Errorf("ThenWithContext should adapt the context error, got %v", r)
+	}
+
+	found := AdaptError[unregisteredTestError](errors.Join(errors.New("outer"), unregisteredTestError{}))
+	if found != (unregisteredTestError{}) {
+		t.Errorf("AdaptError should fall back to errors.As, got %v", found)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AdaptError without an adapter should panic")
+		}
+	}()
+	AdaptError[unregisteredTestError](context.Canceled)
+}
@@ -0,0 +1,127 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for Calls rejected by an open CircuitBreaker.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every Call through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every Call until the open timeout has passed.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial Call through to decide whether to close again.
+	CircuitHalfOpen
+)
+
+// CircuitBreakerOptions configures a CircuitBreaker.
+type CircuitBreakerOptions struct {
+	FailureThreshold int           // Consecutive failures that open the circuit. Values below 1 mean 1.
+	OpenTimeout      time.Duration // How long the circuit stays open before a trial Call is let through.
+	Clock            Clock         // The clock measuring the open timeout. Nil means SystemClock.
+}
+
+// CircuitBreaker stops calling a failing dependency for a while once it has failed too many times in a row.
+// A CircuitBreaker must be created with NewCircuitBreaker and is shared by the Calls wrapped with Breaker.
+type CircuitBreaker struct {
+	opts CircuitBreakerOptions
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int       // Consecutive failures while closed.
+	openedAt time.Time // When the circuit last opened.
+	trial    bool      // Whether the half-open trial Call is in flight.
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker.
+func NewCircuitBreaker(opts CircuitBreakerOptions) *CircuitBreaker {
+	if opts.FailureThreshold < 1 {
+		opts.FailureThreshold = 1
+	}
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+	return &CircuitBreaker{opts: opts}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow reports whether a Call may go through, moving an open circuit to half-open once its timeout has passed.
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if b.opts.Clock.Now().Sub(b.openedAt) < b.opts.OpenTimeout {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.trial = true
+		return true
+	case CircuitHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the circuit with the outcome of a Call that was let through.
+func (b *CircuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitHalfOpen {
+		b.trial = false
+		if failed {
+			b.state = CircuitOpen
+			b.openedAt = b.opts.Clock.Now()
+		} else {
+			b.state = CircuitClosed
+			b.failures = 0
+		}
+		return
+	}
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.opts.FailureThreshold {
+		b.state = CircuitOpen
+		b.openedAt = b.opts.Clock.Now()
+		b.failures = 0
+	}
+}
+
+// Breaker guards every Call with b, failing it with ErrCircuitOpen while the circuit is open.
+// A Call that panics counts as a failure; the panic is re-raised.
+func Breaker(b *CircuitBreaker) Middleware {
+	return func(next Call) Call {
+		return func(ctx context.Context) Result[any, error] {
+			if !b.allow() {
+				return Fail[any, error](ErrCircuitOpen)
+			}
+			failed := true
+			defer func() { b.record(failed) }() // A panic counts as a failure and continues unwinding.
+			r := next(ctx).settle()
+			failed = r.state == Failure
+			return r
+		}
+	}
+}
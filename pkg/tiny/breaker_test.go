@@ -0,0 +1,72 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	b := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 2, OpenTimeout: 10 * time.Millisecond, Clock: clock})
+	fail := true
+	calls := 0
+	call := Breaker(b)(func(context.Context) Result[any, error] {
+		calls++
+		if fail {
+			return Fail[any](errors.New("down"))
+		}
+		return Ok[any, error](calls)
+	})
+
+	call(context.Background())
+	if b.State() != CircuitClosed {
+		t.Errorf("Breaker should stay closed below the threshold, got %v", b.State())
+	}
+	call(context.Background())
+	if b.State() != CircuitOpen {
+		t.Fatalf("Breaker should open at the threshold, got %v", b.State())
+	}
+	if r := call(context.Background()); !errors.Is(r.Unwrap(), ErrCircuitOpen) || calls != 2 {
+		t.Errorf("Breaker should reject calls while open, got %v after %d calls", r, calls)
+	}
+
+	clock.Advance(10 * time.Millisecond)
+	call(context.Background())
+	if b.State() != CircuitOpen || calls != 3 {
+		t.Errorf("Breaker should reopen after a failed trial, got %v after %d calls", b.State(), calls)
+	}
+
+	clock.Advance(9 * time.Millisecond)
+	if r := call(context.Background()); !errors.Is(r.Unwrap(), ErrCircuitOpen) || calls != 3 {
+		t.Errorf("Breaker should reject calls until the open timeout has passed, got %v after %d calls", r, calls)
+	}
+	clock.Advance(time.Millisecond)
+	fail = false
+	if r := call(context.Background()); r.IsErr() || b.State() != CircuitClosed {
+		t.Errorf("Breaker should close after a successful trial, got %v in state %v", r, b.State())
+	}
+}
+
+func TestBreakerPanic(t *testing.T) {
+	b := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+	fail := true
+	call := Breaker(b)(func(context.Context) Result[any, error] {
+		if fail {
+			panic("boom")
+		}
+		return Ok[any, error](1)
+	})
+
+	if p := catchPanic(func() { call(context.Background()) }); p != "boom" || b.State() != CircuitOpen {
+		t.Fatalf("Breaker should re-raise the panic and count it as a failure, got %v in state %v", p, b.State())
+	}
+	if p := catchPanic(func() { call(context.Background()) }); p != "boom" || b.State() != CircuitOpen {
+		t.Fatalf("Breaker should reopen after a panicking trial, got %v in state %v", p, b.State())
+	}
+	fail = false
+	if r := call(context.Background()); r.IsErr() || b.State() != CircuitClosed {
+		t.Errorf("Breaker should let the next trial through after a panicking one, got %v in state %v", r, b.State())
+	}
+}
@@ -0,0 +1,166 @@
+package tiny
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheOptions configures a Cache.
+type CacheOptions struct {
+	OkTTL      time.Duration // How long a Success stays fresh. Zero means forever.
+	FailTTL    time.Duration // How long a Failure stays fresh. Zero means Failures are not cached.
+	MaxEntries int           // The most entries kept before the least recently used is evicted. Zero means no bound.
+	// StaleWhileRevalidate is how long after going stale a Success is still served by GetOrLoad
+	// while it is refreshed in the background. Zero disables it.
+	StaleWhileRevalidate time.Duration
+	Clock                Clock // The clock measuring the lifetimes. Nil means SystemClock.
+}
+
+// Cache stores Result outcomes by key, with separate lifetimes for Successes and Failures.
+// Caching a Failure for a short time shields a failing backend from every caller retrying at once.
+// A Cache must be created with NewCache.
+type Cache[K comparable, T any, E error] struct {
+	opts CacheOptions
+
+	mu      sync.Mutex
+	entries map[K]*list.Element // Elements hold *cacheEntry values.
+	lru     *list.List          // Most recently used at the front.
+	loads   Group[K, T, E]      // Deduplicates concurrent loads of the same key.
+}
+
+// cacheEntry is a cached Result and the time it was stored.
+type cacheEntry[K comparable, T any, E error] struct {
+	key    K
+	result Result[T, E]
+	stored time.Time
+}
+
+// NewCache creates an empty Cache.
+//
+// Example:
+//
+//	users := NewCache[string, *User, error](CacheOptions{OkTTL: time.Minute, FailTTL: 5 * time.Second, MaxEntries: 10000})
+//	u := users.GetOrLoad(ctx, id, func(ctx context.Context) Result[*User, error] {
+//	    return fetchUser(ctx, id)
+//	})
+func NewCache[K comparable, T any, E error](opts CacheOptions) *Cache[K, T, E] {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+	return &Cache[K, T, E]{
+		opts:    opts,
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the fresh Result cached for k, or an Unset Result and false if there is none.
+func (c *Cache[K, T, E]) Get(k K) (Result[T, E], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, fresh, _ := c.lookup(k, c.opts.Clock.Now())
+	if !fresh {
+		return Result[T, E]{}, false
+	}
+	return e.result, true
+}
+
+// Set stores r for k, unless r is a Failure and Failures are not cached.
+func (c *Cache[K, T, E]) Set(k K, r Result[T, E]) {
+	r = r.settle()
+	if r.state == Failure && c.opts.FailTTL <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &cacheEntry[K, T, E]{key: k, result: r, stored: c.opts.Clock.Now()}
+	if el, ok := c.entries[k]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[k] = c.lru.PushFront(entry)
+	if c.opts.MaxEntries > 0 && c.lru.Len() > c.opts.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Delete removes the entry for k, if any.
+func (c *Cache[K, T, E]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[k]; ok {
+		c.remove(el)
+	}
+}
+
+// Len returns the number of entries, including stale ones not yet evicted.
+func (c *Cache[K, T, E]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// GetOrLoad returns the fresh Result cached for k, or loads, caches and returns a new one.
+// Concurrent loads of the same key are folded into one, as with Group.Do.
+// A stale Success still within StaleWhileRevalidate is returned immediately while loader refreshes it in the background.
+func (c *Cache[K, T, E]) GetOrLoad(ctx context.Context, k K, loader func(context.Context) Result[T, E]) Result[T, E] {
+	c.mu.Lock()
+	e, fresh, revalidate := c.lookup(k, c.opts.Clock.Now())
+	c.mu.Unlock()
+	if fresh {
+		return e.result
+	}
+	if revalidate {
+		go c.load(context.WithoutCancel(ctx), k, loader)
+		return e.result
+	}
+	return c.load(ctx, k, loader)
+}
+
+// load runs loader for k through the load Group and caches its Result.
+// A Failure of a load abandoned by every caller is not cached: it is most likely the context error,
+// which says nothing about k.
+func (c *Cache[K, T, E]) load(ctx context.Context, k K, loader func(context.Context) Result[T, E]) Result[T, E] {
+	return c.loads.Do(ctx, k, func(ctx context.Context) Result[T, E] {
+		r := loader(ctx)
+		if r.IsErr() && ctx.Err() != nil {
+			return r
+		}
+		c.Set(k, r)
+		return r
+	})
+}
+
+// lookup finds the entry for k and reports whether it is fresh, or stale but still usable while it is revalidated.
+// Entries that are neither are removed. c.mu must be held.
+func (c *Cache[K, T, E]) lookup(k K, now time.Time) (entry *cacheEntry[K, T, E], fresh, revalidate bool) {
+	el, ok := c.entries[k]
+	if !ok {
+		return nil, false, false
+	}
+	entry = el.Value.(*cacheEntry[K, T, E])
+	ttl := c.opts.OkTTL
+	if entry.result.state == Failure {
+		ttl = c.opts.FailTTL
+	}
+	age := now.Sub(entry.stored)
+	switch {
+	case ttl <= 0 && entry.result.state != Failure, age < ttl:
+		c.lru.MoveToFront(el)
+		return entry, true, false
+	case entry.result.state != Failure && age < ttl+c.opts.StaleWhileRevalidate:
+		return entry, false, true
+	default:
+		c.remove(el)
+		return nil, false, false
+	}
+}
+
+// remove deletes el from the cache. c.mu must be held.
+func (c *Cache[K, T, E]) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry[K, T, E]).key)
+}
@@ -0,0 +1,134 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func countingLoader(calls *atomic.Int32, err error) func(context.Context) Result[int32, error] {
+	return func(context.Context) Result[int32, error] {
+		n := calls.Add(1)
+		if err != nil {
+			return Fail[int32](err)
+		}
+		return Ok[int32, error](n)
+	}
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	errDown := errors.New("backend down")
+	tests := []struct {
+		name      string
+		opts      CacheOptions
+		err       error
+		wantCalls int32
+	}{
+		{name: "success cached", opts: CacheOptions{OkTTL: time.Minute}, wantCalls: 1},
+		{name: "failure cached", opts: CacheOptions{OkTTL: time.Minute, FailTTL: time.Minute}, err: errDown, wantCalls: 1},
+		{name: "failure not cached", opts: CacheOptions{OkTTL: time.Minute}, err: errDown, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache[string, int32, error](tt.opts)
+			var calls atomic.Int32
+			for i := 0; i < 3; i++ {
+				r := c.GetOrLoad(context.Background(), "k", countingLoader(&calls, tt.err))
+				if tt.err != nil && !errors.Is(r.Unwrap(), tt.err) {
+					t.Errorf("GetOrLoad() = %v, want %v", r, tt.err)
+				}
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("GetOrLoad() loaded %d times, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCacheAbandonedLoad(t *testing.T) {
+	c := NewCache[string, int32, error](CacheOptions{OkTTL: time.Minute, FailTTL: time.Minute})
+	finished := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	r := c.GetOrLoad(ctx, "k", func(loadCtx context.Context) Result[int32, error] {
+		defer close(finished)
+		cancel() // The only caller leaves, which cancels the load.
+		<-loadCtx.Done()
+		return Fail[int32](loadCtx.Err())
+	})
+	if !errors.Is(r.Unwrap(), context.Canceled) {
+		t.Fatalf("GetOrLoad() = %v, want %v", r, context.Canceled)
+	}
+
+	<-finished
+	time.Sleep(10 * time.Millisecond) // Let the abandoned load return to the cache.
+	if got, ok := c.Get("k"); ok {
+		t.Errorf("Get() = %v, want the abandoned load not cached", got)
+	}
+	var calls atomic.Int32
+	if got := c.GetOrLoad(context.Background(), "k", countingLoader(&calls, nil)); got.OrElse(0) != 1 {
+		t.Errorf("GetOrLoad() after an abandoned load = %v, want Ok(1)", got)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	c := NewCache[string, int32, error](CacheOptions{OkTTL: 10 * time.Millisecond, Clock: clock})
+	var calls atomic.Int32
+	c.GetOrLoad(context.Background(), "k", countingLoader(&calls, nil))
+	clock.Advance(9 * time.Millisecond)
+	if _, ok := c.Get("k"); !ok {
+		t.Errorf("Get() should find a fresh entry")
+	}
+	clock.Advance(time.Millisecond)
+	if _, ok := c.Get("k"); ok {
+		t.Errorf("Get() should not return an expired entry")
+	}
+	if r := c.GetOrLoad(context.Background(), "k", countingLoader(&calls, nil)); r.OrElse(0) != 2 {
+		t.Errorf("GetOrLoad() after expiry = %v, want a reload", r)
+	}
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	c := NewCache[string, int32, error](CacheOptions{OkTTL: 10 * time.Millisecond, StaleWhileRevalidate: time.Minute, Clock: clock})
+	var calls atomic.Int32
+	c.GetOrLoad(context.Background(), "k", countingLoader(&calls, nil))
+	clock.Advance(20 * time.Millisecond)
+
+	if r := c.GetOrLoad(context.Background(), "k", countingLoader(&calls, nil)); r.OrElse(0) != 1 {
+		t.Errorf("GetOrLoad() on a stale entry = %v, want the stale value", r)
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if r, ok := c.Get("k"); ok && r.OrElse(0) == 2 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Errorf("GetOrLoad() should refresh a stale entry in the background")
+}
+
+func TestCacheLRU(t *testing.T) {
+	c := NewCache[string, int, error](CacheOptions{MaxEntries: 2})
+	c.Set("a", Ok[int, error](1))
+	c.Set("b", Ok[int, error](2))
+	c.Get("a")
+	c.Set("c", Ok[int, error](3))
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Set() should evict the least recently used entry")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Set() should keep a recently used entry")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok || c.Len() != 1 {
+		t.Errorf("Delete() should remove the entry")
+	}
+}
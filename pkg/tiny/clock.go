@@ -0,0 +1,211 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and measures timeouts for the helpers that wait.
+// The timeout helpers accept one through WithClock and Retry through RetryOptions.Clock,
+// so that tests can replace the system clock with a FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a Timer that fires once d has passed.
+	NewTimer(d time.Duration) Timer
+	// WithTimeout returns a copy of ctx that is canceled with context.DeadlineExceeded once d has passed, like context.WithTimeout.
+	WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+// Timer is a single event created by a Clock, like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the Timer fires.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing, reporting false if it already fired or was stopped.
+	Stop() bool
+}
+
+// SystemClock is the Clock of the time and context packages. It is the default everywhere a Clock is accepted.
+type SystemClock struct{}
+
+// Now returns time.Now().
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer returns a time.Timer.
+func (SystemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// WithTimeout returns context.WithTimeout(ctx, d).
+func (SystemClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+// systemTimer adapts a time.Timer to Timer.
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time { return t.t.C }
+func (t systemTimer) Stop() bool          { return t.t.Stop() }
+
+// TimeOption configures the clock of a timeout helper.
+type TimeOption func(*timeOptions)
+
+type timeOptions struct {
+	clock Clock // The clock measuring the timeout.
+}
+
+// WithClock makes a timeout helper measure time with c instead of the system clock.
+func WithClock(c Clock) TimeOption {
+	return func(o *timeOptions) {
+		o.clock = c
+	}
+}
+
+// clockOf returns the clock set by opts, or the system clock.
+func clockOf(opts []TimeOption) Clock {
+	o := timeOptions{clock: SystemClock{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.clock
+}
+
+// FakeClock is a Clock that only moves when Advance is called, for deterministic tests of timeouts and backoff.
+// A FakeClock must be created with NewFakeClock.
+//
+// Example:
+//
+//	clock := NewFakeClock(time.Now())
+//	ch := AsyncThenWithTimeout(r, slow, time.Minute, WithClock(clock))
+//	clock.BlockUntil(1)        // Wait until the timeout is armed.
+//	clock.Advance(time.Minute) // Fire it instantly.
+//	result := <-ch
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond // Broadcast whenever a timer is created.
+	now     time.Time
+	pending []*fakeTimer // Timers that have not fired or been stopped, in no particular order.
+}
+
+// fakeTimer is a Timer of a FakeClock.
+type fakeTimer struct {
+	clock *FakeClock
+	ch    chan time.Time
+	when  time.Time
+}
+
+// NewFakeClock creates a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a Timer that fires once the clock has been advanced by d. A Timer for d <= 0 fires immediately.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1), when: c.now.Add(d)}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.pending = append(c.pending, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// WithTimeout returns a copy of ctx that is canceled with context.DeadlineExceeded once the clock has been advanced by d.
+// Its timer counts as pending for BlockUntil until it fires or the context is canceled.
+func (c *FakeClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	inner, cancel := context.WithCancelCause(ctx)
+	deadline := c.Now().Add(d)
+	timer := c.NewTimer(d)
+	go func() {
+		select {
+		case <-timer.C():
+			cancel(context.DeadlineExceeded)
+		case <-inner.Done():
+			timer.Stop()
+		}
+	}()
+	return fakeTimeoutContext{Context: inner, deadline: deadline}, func() { cancel(context.Canceled) }
+}
+
+// fakeTimeoutContext is the context of FakeClock.WithTimeout, reporting its fake deadline.
+type fakeTimeoutContext struct {
+	context.Context // Canceled with the cause context.DeadlineExceeded when the deadline passes.
+	deadline        time.Time
+}
+
+func (c fakeTimeoutContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c fakeTimeoutContext) Err() error {
+	err := c.Context.Err()
+	if err != nil && errors.Is(context.Cause(c.Context), context.DeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// Advance moves the clock forward by d and fires, in order, every timer that is due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.pending, func(i, j int) bool {
+		return c.pending[i].when.Before(c.pending[j].when)
+	})
+	fired := 0
+	for _, t := range c.pending {
+		if t.when.After(c.now) {
+			break
+		}
+		t.ch <- t.when
+		fired++
+	}
+	c.pending = c.pending[fired:]
+}
+
+// BlockUntil waits until at least n timers are pending, so a test can advance the clock
+// only once the code under test, running on another goroutine, has started waiting.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.pending) < n {
+		c.cond.Wait()
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, p := range c.pending {
+		if p == t {
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
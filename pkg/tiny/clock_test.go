@@ -0,0 +1,86 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	late := c.NewTimer(2 * time.Second)
+	early := c.NewTimer(time.Second)
+	stopped := c.NewTimer(time.Second)
+	if !stopped.Stop() || stopped.Stop() {
+		t.Errorf("Stop() should report true only for a pending timer")
+	}
+
+	c.Advance(time.Second)
+	if got := c.Now(); !got.Equal(start.Add(time.Second)) {
+		t.Errorf("Now() = %v, want one second after start", got)
+	}
+	select {
+	case got := <-early.C():
+		if !got.Equal(start.Add(time.Second)) {
+			t.Errorf("early fired at %v", got)
+		}
+	default:
+		t.Errorf("early should have fired")
+	}
+	select {
+	case <-late.C():
+		t.Errorf("late should not fire before two seconds")
+	case <-stopped.C():
+		t.Errorf("stopped should never fire")
+	default:
+	}
+
+	c.Advance(time.Second)
+	select {
+	case <-late.C():
+	default:
+		t.Errorf("late should have fired")
+	}
+	if late.Stop() {
+		t.Errorf("Stop() after firing should report false")
+	}
+	select {
+	case <-c.NewTimer(0).C():
+	default:
+		t.Errorf("a timer for zero should fire immediately")
+	}
+}
+
+func TestFakeClockBlockUntil(t *testing.T) {
+	c := NewFakeClock(time.Now())
+	fired := make(chan struct{})
+	go func() {
+		<-c.NewTimer(time.Minute).C()
+		close(fired)
+	}()
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+	<-fired
+}
+
+func TestFakeClockWithTimeout(t *testing.T) {
+	c := NewFakeClock(time.Now())
+	ctx, cancel := c.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || !deadline.Equal(c.Now().Add(time.Minute)) {
+		t.Errorf("Deadline() = %v, %v, want one minute from now", deadline, ok)
+	}
+	c.Advance(time.Minute)
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err() = %v, want context.DeadlineExceeded", ctx.Err())
+	}
+
+	ctx, cancel = c.WithTimeout(context.Background(), time.Minute)
+	cancel()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Err() after cancel = %v, want context.Canceled", ctx.Err())
+	}
+}
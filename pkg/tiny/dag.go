@@ -0,0 +1,290 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrCycle is returned by Workflow.Build when the task dependencies form a cycle.
+	ErrCycle = errors.New("dependency cycle")
+	// ErrDependencyFailed is matched by the Failure of every task skipped because a dependency failed.
+	ErrDependencyFailed = errors.New("dependency failed")
+)
+
+// SkippedError is the Failure of a task that never ran because one of its dependencies failed.
+type SkippedError struct {
+	Task       string // The skipped task.
+	Dependency string // The dependency that failed or was itself skipped.
+}
+
+// Error names the skipped task and the dependency responsible.
+func (e *SkippedError) Error() string {
+	return fmt.Sprintf("task %s skipped: dependency %s failed", e.Task, e.Dependency)
+}
+
+// Unwrap returns ErrDependencyFailed.
+func (e *SkippedError) Unwrap() error {
+	return ErrDependencyFailed
+}
+
+// WorkflowOptions configures a Workflow.
+type WorkflowOptions struct {
+	Concurrency int // The most tasks running at once. Zero means no limit.
+}
+
+// Workflow is a set of tasks that depend on each other's outputs.
+// Tasks are added with Task, then Build checks the dependencies and returns a Plan to run.
+type Workflow struct {
+	opts  WorkflowOptions
+	tasks map[string]*workflowTask
+	order []string // Task names in the order they were added.
+	err   error    // The first error found while adding tasks.
+}
+
+// workflowTask is a task with its types erased.
+type workflowTask struct {
+	name string
+	deps []string
+	run  func(context.Context, Inputs) Result[any, error]
+}
+
+// NewWorkflow creates an empty Workflow.
+func NewWorkflow(opts WorkflowOptions) *Workflow {
+	return &Workflow{opts: opts, tasks: make(map[string]*workflowTask)}
+}
+
+// Inputs gives a task the outputs of its dependencies.
+type Inputs struct {
+	task   string
+	values map[string]any
+}
+
+// Input returns the output of the dependency name, which must have been declared by the task and produce a T.
+// It panics otherwise, as that is a mistake in the workflow definition.
+func Input[T any](in Inputs, name string) T {
+	v, ok := in.values[name]
+	if !ok {
+		panic(fmt.Sprintf("tiny: task %s reads %s, which is not one of its dependencies", in.task, name))
+	}
+	out, ok := v.(T)
+	if !ok && v != nil {
+		panic(fmt.Sprintf("tiny: task %s reads %s as %T, but it produced %T", in.task, name, out, v))
+	}
+	return out
+}
+
+// Task adds a task named name to w. fn runs once every task in deps has succeeded,
+// and reads their outputs with Input.
+//
+// Example:
+//
+//	w := NewWorkflow(WorkflowOptions{Concurrency: 4})
+//	Task(w, "compile", nil, compile)
+//	Task(w, "test", []string{"compile"}, func(ctx context.Context, in Inputs) Result[Report, error] {
+//	    return runTests(ctx, Input[Binary](in, "compile"))
+//	})
+//	report := w.Build().UnwrapOrPanic().Run(ctx)
+func Task[T any, E error](w *Workflow, name string, deps []string, fn func(context.Context, Inputs) Result[T, E]) {
+	if _, ok := w.tasks[name]; ok {
+		if w.err == nil {
+			w.err = fmt.Errorf("duplicate task %s", name)
+		}
+		return
+	}
+	w.tasks[name] = &workflowTask{
+		name: name,
+		deps: deps,
+		run: func(ctx context.Context, in Inputs) Result[any, error] {
+			r := fn(ctx, in).settle()
+			if r.state == Failure {
+				return propagate[any, error](r, r.fault)
+			}
+			return Ok[any, error](r.value)
+		},
+	}
+	w.order = append(w.order, name)
+}
+
+// Build checks that every dependency names a task and that there is no cycle.
+// It returns a Failure Result, matching ErrCycle for a cycle, if the workflow cannot run.
+func (w *Workflow) Build() Result[*Plan, error] {
+	if w.err != nil {
+		return Fail[*Plan](w.err)
+	}
+	pending := make(map[string]int, len(w.tasks))
+	dependents := make(map[string][]string, len(w.tasks))
+	for _, name := range w.order {
+		t := w.tasks[name]
+		for _, dep := range t.deps {
+			if _, ok := w.tasks[dep]; !ok {
+				return Fail[*Plan](fmt.Errorf("task %s depends on unknown task %s", name, dep))
+			}
+			dependents[dep] = append(dependents[dep], name)
+		}
+		pending[name] = len(t.deps)
+	}
+
+	// Kahn's algorithm: whatever cannot be ordered is part of, or behind, a cycle.
+	ready := make([]string, 0, len(w.order))
+	for _, name := range w.order {
+		if pending[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	left := make(map[string]int, len(pending))
+	for name, n := range pending {
+		left[name] = n
+	}
+	for i := 0; i < len(ready); i++ {
+		for _, d := range dependents[ready[i]] {
+			if left[d]--; left[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+	if len(ready) < len(w.order) {
+		var stuck []string
+		for name, n := range left {
+			if n > 0 {
+				stuck = append(stuck, name)
+			}
+		}
+		sort.Strings(stuck)
+		return Fail[*Plan](fmt.Errorf("%w among tasks %s", ErrCycle, strings.Join(stuck, ", ")))
+	}
+	return Ok[*Plan, error](&Plan{workflow: w, pending: pending, dependents: dependents})
+}
+
+// Plan is a checked Workflow, ready to run.
+type Plan struct {
+	workflow   *Workflow
+	pending    map[string]int      // The number of dependencies of each task.
+	dependents map[string][]string // The tasks depending on each task.
+}
+
+// Report holds the Result of every task of a run.
+type Report struct {
+	Results map[string]Result[any, error]
+}
+
+// Output returns the Result of the task name as a Result of T.
+// A task that did not produce a T fails with a descriptive error.
+func Output[T any](r Report, name string) Result[T, error] {
+	res, ok := r.Results[name]
+	if !ok {
+		return Fail[T](fmt.Errorf("no task %s in report", name))
+	}
+	if res.state == Failure {
+		return propagate[T](res, res.fault)
+	}
+	out, ok := res.value.(T)
+	if !ok && res.value != nil {
+		return Fail[T](fmt.Errorf("task %s produced %T, not %T", name, res.value, out))
+	}
+	return Ok[T, error](out)
+}
+
+// Failed returns the names of the tasks that failed or were skipped, sorted.
+func (r Report) Failed() []string {
+	var names []string
+	for name, res := range r.Results {
+		if res.state == Failure {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs every task once its dependencies have succeeded, running independent tasks concurrently
+// within the concurrency limit. When a task fails, the tasks depending on it, directly or not, are skipped
+// with a *SkippedError, while unrelated tasks carry on. Tasks not yet started when ctx is done fail with the context error.
+// Run returns once every task has a Result.
+func (p *Plan) Run(ctx context.Context) Report {
+	w := p.workflow
+	results := make(map[string]Result[any, error], len(w.order))
+	outputs := make(map[string]any, len(w.order))
+	left := make(map[string]int, len(p.pending))
+	for name, n := range p.pending {
+		left[name] = n
+	}
+
+	type finished struct {
+		name   string
+		result Result[any, error]
+	}
+	done := make(chan finished)
+	var slots chan struct{}
+	if w.opts.Concurrency > 0 {
+		slots = make(chan struct{}, w.opts.Concurrency)
+	}
+	running := 0
+	start := func(name string) {
+		t := w.tasks[name]
+		in := Inputs{task: name, values: make(map[string]any, len(t.deps))}
+		for _, dep := range t.deps {
+			in.values[dep] = outputs[dep]
+		}
+		running++
+		go func() {
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+					done <- finished{name, Fail[any](ctx.Err())}
+					return
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				done <- finished{name, Fail[any](err)}
+				return
+			}
+			done <- finished{name, t.run(ctx, in)}
+		}()
+	}
+
+	// skip fails every task behind a failed one, breadth first.
+	skip := func(failed string) {
+		queue := []string{failed}
+		for len(queue) > 0 {
+			dep := queue[0]
+			queue = queue[1:]
+			for _, d := range p.dependents[dep] {
+				if _, ok := results[d]; ok {
+					continue
+				}
+				results[d] = Fail[any, error](&SkippedError{Task: d, Dependency: dep})
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	for _, name := range w.order {
+		if left[name] == 0 {
+			start(name)
+		}
+	}
+	for running > 0 {
+		f := <-done
+		running--
+		results[f.name] = f.result
+		if f.result.state == Failure {
+			skip(f.name)
+			continue
+		}
+		outputs[f.name] = f.result.value
+		for _, d := range p.dependents[f.name] {
+			if left[d]--; left[d] == 0 {
+				if _, skipped := results[d]; !skipped {
+					start(d)
+				}
+			}
+		}
+	}
+	return Report{Results: results}
+}
@@ -0,0 +1,145 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkflowRun(t *testing.T) {
+	errTest := errors.New("tests failed")
+	w := NewWorkflow(WorkflowOptions{})
+	Task(w, "fetch", nil, func(context.Context, Inputs) Result[string, error] {
+		return Ok[string, error]("src")
+	})
+	Task(w, "compile", []string{"fetch"}, func(_ context.Context, in Inputs) Result[string, error] {
+		return Ok[string, error](Input[string](in, "fetch") + ".bin")
+	})
+	Task(w, "test", []string{"compile"}, func(context.Context, Inputs) Result[int, error] {
+		return Fail[int](errTest)
+	})
+	Task(w, "deploy", []string{"compile", "test"}, func(context.Context, Inputs) Result[bool, error] {
+		t.Errorf("deploy should not run after test failed")
+		return Ok[bool, error](true)
+	})
+	Task(w, "notify", []string{"deploy"}, func(context.Context, Inputs) Result[bool, error] {
+		t.Errorf("notify should not run after deploy was skipped")
+		return Ok[bool, error](true)
+	})
+	Task(w, "docs", []string{"fetch"}, func(context.Context, Inputs) Result[int, error] {
+		return Ok[int, error](3)
+	})
+
+	report := w.Build().UnwrapOrPanic().Run(context.Background())
+
+	if got := Output[string](report, "compile"); got.OrElse("") != "src.bin" {
+		t.Errorf("compile = %v, want src.bin", got)
+	}
+	if got := Output[int](report, "docs"); got.OrElse(0) != 3 {
+		t.Errorf("docs = %v, want an unrelated branch to finish", got)
+	}
+	if got := report.Results["test"]; !errors.Is(got.Unwrap(), errTest) {
+		t.Errorf("test = %v, want %v", got, errTest)
+	}
+	var skipped *SkippedError
+	if got := report.Results["notify"]; !errors.As(got.Unwrap(), &skipped) || skipped.Dependency != "deploy" {
+		t.Errorf("notify = %v, want skipped because of deploy", got)
+	}
+	if got := report.Failed(); !reflect.DeepEqual(got, []string{"deploy", "notify", "test"}) {
+		t.Errorf("Failed() = %v", got)
+	}
+}
+
+func TestWorkflowConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	w := NewWorkflow(WorkflowOptions{Concurrency: 2})
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		Task(w, name, nil, func(context.Context, Inputs) Result[int, error] {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return Ok[int, error](1)
+		})
+	}
+
+	report := w.Build().UnwrapOrPanic().Run(context.Background())
+	if len(report.Failed()) != 0 || len(report.Results) != 5 {
+		t.Errorf("Run() = %v, want five successes", report.Results)
+	}
+	if peak.Load() > 2 {
+		t.Errorf("Run() ran %d tasks at once, want at most 2", peak.Load())
+	}
+}
+
+func TestWorkflowBuild(t *testing.T) {
+	noop := func(context.Context, Inputs) Result[int, error] { return Ok[int, error](0) }
+	tests := []struct {
+		name    string
+		build   func(w *Workflow)
+		wantErr error
+	}{
+		{
+			name: "cycle",
+			build: func(w *Workflow) {
+				Task(w, "a", []string{"c"}, noop)
+				Task(w, "b", []string{"a"}, noop)
+				Task(w, "c", []string{"b"}, noop)
+				Task(w, "d", nil, noop)
+			},
+			wantErr: ErrCycle,
+		},
+		{
+			name: "unknown dependency",
+			build: func(w *Workflow) {
+				Task(w, "a", []string{"missing"}, noop)
+			},
+		},
+		{
+			name: "duplicate task",
+			build: func(w *Workflow) {
+				Task(w, "a", nil, noop)
+				Task(w, "a", nil, noop)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorkflow(WorkflowOptions{})
+			tt.build(w)
+			r := w.Build()
+			if r.IsOk() {
+				t.Fatalf("Build() should fail")
+			}
+			if tt.wantErr != nil && !errors.Is(r.Unwrap(), tt.wantErr) {
+				t.Errorf("Build() = %v, want %v", r, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflowCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := NewWorkflow(WorkflowOptions{})
+	Task(w, "first", nil, func(context.Context, Inputs) Result[int, error] {
+		cancel()
+		return Ok[int, error](1)
+	})
+	Task(w, "second", []string{"first"}, func(context.Context, Inputs) Result[int, error] {
+		return Ok[int, error](2)
+	})
+
+	report := w.Build().UnwrapOrPanic().Run(ctx)
+	if got := report.Results["second"]; !errors.Is(got.Unwrap(), context.Canceled) {
+		t.Errorf("second = %v, want context.Canceled", got)
+	}
+}
@@ -0,0 +1,143 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// generator is the method set of quick.Generator. The package does not import testing/quick,
+// whose init would add a -quickchecks flag to every program importing tiny.
+type generator interface {
+	Generate(rand *rand.Rand, size int) reflect.Value
+}
+
+// Generate implements quick.Generator, so testing/quick can produce random Results:
+// a Success or a Failure with equal probability. The value is generated the way quick.Value would,
+// using the Generate method of T if it has one, with slices, maps and strings of up to size elements.
+// If E is an interface type such as error, the error is an errors.New with a random message;
+// if E is a pointer type, it points to a random value; otherwise it is a random E.
+// Generate panics if T or E is a kind quick.Value cannot generate either, such as a channel.
+func (Result[T, E]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if rand.Intn(2) == 0 {
+		return reflect.ValueOf(Ok[T, E](randomOf[T](rand, size)))
+	}
+	var fault E
+	switch errType := reflect.TypeFor[E](); errType.Kind() {
+	case reflect.Interface:
+		e, ok := any(errors.New(randomOf[string](rand, size))).(E)
+		if !ok {
+			panic(fmt.Sprintf("tiny: cannot generate a %v", errType))
+		}
+		fault = e
+	case reflect.Pointer:
+		// Never a nil pointer, which would not be an error at all.
+		p := reflect.New(errType.Elem())
+		p.Elem().Set(mustRandom(errType.Elem(), rand, size))
+		fault = p.Interface().(E)
+	default:
+		fault = randomOf[E](rand, size)
+	}
+	return reflect.ValueOf(Fail[T, E](fault))
+}
+
+// randomOf generates a random V, panicking if it cannot.
+func randomOf[V any](rand *rand.Rand, size int) V {
+	return mustRandom(reflect.TypeFor[V](), rand, size).Interface().(V)
+}
+
+// mustRandom generates a random value of type t, panicking if it cannot.
+func mustRandom(t reflect.Type, rand *rand.Rand, size int) reflect.Value {
+	v, ok := randomValue(t, rand, size)
+	if !ok {
+		panic(fmt.Sprintf("tiny: cannot generate a %v", t))
+	}
+	return v
+}
+
+// randomValue generates a random value of type t as quick.Value does, reporting false for kinds it cannot generate.
+// Only the exported fields of a struct are set.
+func randomValue(t reflect.Type, rand *rand.Rand, size int) (reflect.Value, bool) {
+	size = max(size, 1)
+	if g, ok := reflect.Zero(t).Interface().(generator); ok {
+		return g.Generate(rand, size), true
+	}
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(rand.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(rand.Uint64()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(rand.Uint64())
+	case reflect.Float32:
+		v.SetFloat((rand.Float64()*2 - 1) * math.MaxFloat32)
+	case reflect.Float64:
+		v.SetFloat((rand.Float64()*2 - 1) * math.MaxFloat64)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(rand.NormFloat64(), rand.NormFloat64()))
+	case reflect.String:
+		runes := make([]rune, rand.Intn(size))
+		for i := range runes {
+			runes[i] = rune(rand.Intn(0x10ffff))
+		}
+		v.SetString(string(runes))
+	case reflect.Slice:
+		n := rand.Intn(size)
+		v.Set(reflect.MakeSlice(t, n, n))
+		for i := range n {
+			elem, ok := randomValue(t.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Index(i).Set(elem)
+		}
+	case reflect.Array:
+		for i := range t.Len() {
+			elem, ok := randomValue(t.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(t))
+		for range rand.Intn(size) {
+			key, ok := randomValue(t.Key(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			elem, ok := randomValue(t.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Pointer:
+		if rand.Intn(size) == 0 {
+			return v, true // A nil pointer, as quick.Value generates once in a while.
+		}
+		elem, ok := randomValue(t.Elem(), rand, size)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(elem)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			elem, ok := randomValue(t.Field(i).Type, rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Field(i).Set(elem)
+		}
+	default:
+		return reflect.Value{}, false
+	}
+	return v, true
+}
@@ -0,0 +1,83 @@
+package tiny
+
+import (
+	"go/build"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestGenerate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var oks, fails int
+	for range 100 {
+		r := Result[int, error]{}.Generate(rng, 10).Interface().(Result[int, error])
+		if r.IsOk() {
+			oks++
+		} else if r.Unwrap() != nil {
+			fails++
+		}
+	}
+	if oks == 0 || fails == 0 || oks+fails != 100 {
+		t.Errorf("Generate() gave %d successes and %d failures with an error, want both", oks, fails)
+	}
+
+	for range 20 {
+		r := Result[string, *jsonTestError]{}.Generate(rng, 10).Interface().(Result[string, *jsonTestError])
+		if r.IsErr() && r.Unwrap() == nil {
+			t.Fatalf("Generate() = %v, want a non-nil pointer error", r)
+		}
+	}
+}
+
+func TestGenerateWithQuick(t *testing.T) {
+	roundTrip := func(r Result[int, error]) bool {
+		return r.IsOk() != r.IsErr()
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRandomValue(t *testing.T) {
+	type record struct {
+		ID     int
+		Tags   []string
+		Scores map[string]float64
+		Next   *record
+		hidden chan int
+	}
+	rng := rand.New(rand.NewSource(1))
+	types := []reflect.Type{
+		reflect.TypeFor[bool](),
+		reflect.TypeFor[int8](),
+		reflect.TypeFor[uint64](),
+		reflect.TypeFor[float32](),
+		reflect.TypeFor[complex128](),
+		reflect.TypeFor[string](),
+		reflect.TypeFor[[3]byte](),
+		reflect.TypeFor[record](),
+		reflect.TypeFor[Result[int, error]](),
+	}
+	for _, typ := range types {
+		v, ok := randomValue(typ, rng, 10)
+		if !ok || v.Type() != typ {
+			t.Errorf("randomValue(%v) = %v, %v, want a %v", typ, v, ok, typ)
+		}
+	}
+	if _, ok := randomValue(reflect.TypeFor[chan int](), rng, 10); ok {
+		t.Errorf("randomValue(chan int) should report that it cannot generate a channel")
+	}
+}
+
+func TestNoQuickImport(t *testing.T) {
+	pkg, err := build.ImportDir(".", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(pkg.Imports, "testing/quick") {
+		t.Errorf("tiny imports testing/quick, which adds a -quickchecks flag to every program")
+	}
+}
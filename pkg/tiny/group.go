@@ -0,0 +1,96 @@
+package tiny
+
+import (
+	"context"
+	"sync"
+)
+
+// Group folds concurrent calls with the same key into a single execution whose Result is shared by every caller.
+// The zero value is ready to use.
+type Group[K comparable, T any, E error] struct {
+	mu    sync.Mutex
+	calls map[K]*groupCall[T, E] // The executions in flight, by key.
+}
+
+// groupCall is a single execution shared by the callers of Group.Do.
+type groupCall[T any, E error] struct {
+	done    chan struct{}      // Closed once result or panic is set.
+	result  Result[T, E]       // The shared outcome.
+	panic   any                // The value fn panicked with, if it did.
+	waiters int                // How many callers are still waiting.
+	cancel  context.CancelFunc // Cancels the context of the execution.
+}
+
+// Do runs fn for key, unless an execution for key is already in flight, in which case it waits for that one instead.
+// The execution runs with a context carrying the values of the first caller's ctx, but not its cancellation.
+// If ctx is done first, Do returns a Failure Result with the context error, converted into E with AdaptError,
+// and leaves the execution running for the other callers. Once every caller has left, the execution's context is canceled.
+// If fn panics, the panic is re-raised in every caller still waiting.
+//
+// Example:
+//
+//	var users Group[string, *User, error]
+//	u := users.Do(ctx, id, func(ctx context.Context) Result[*User, error] {
+//	    return fetchUser(ctx, id)
+//	})
+func (g *Group[K, T, E]) Do(ctx context.Context, key K, fn func(context.Context) Result[T, E]) Result[T, E] {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*groupCall[T, E])
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &groupCall[T, E]{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.panic != nil {
+			panic(c.panic)
+		}
+		return c.result
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mu.Unlock()
+		return Fail[T, E](AdaptError[E](ctx.Err()))
+	}
+}
+
+// Forget makes the next Do for key start a new execution, even if one is still in flight.
+// Callers already waiting keep waiting for the old one.
+func (g *Group[K, T, E]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
+
+// run executes fn for c and releases every waiter, even if fn panics.
+func (g *Group[K, T, E]) run(ctx context.Context, key K, c *groupCall[T, E], fn func(context.Context) Result[T, E]) {
+	defer func() {
+		c.panic = recover()
+		c.cancel()
+		g.mu.Lock()
+		g.forget(key, c)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.result = fn(ctx)
+}
+
+// forget removes c from the calls in flight, unless key has already moved on to another execution.
+// g.mu must be held.
+func (g *Group[K, T, E]) forget(key K, c *groupCall[T, E]) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
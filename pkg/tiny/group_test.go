@@ -0,0 +1,129 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupDo(t *testing.T) {
+	var g Group[string, int, error]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(context.Context) Result[int, error] {
+		calls.Add(1)
+		<-release
+		return Ok[int, error](7)
+	}
+
+	var wg sync.WaitGroup
+	results := make([]Result[int, error], 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = g.Do(context.Background(), "k", fn)
+		}(i)
+	}
+	waitForWaiters(&g, "k", len(results))
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Group.Do() should execute once, got %d", calls.Load())
+	}
+	for _, r := range results {
+		if r.OrElse(0) != 7 {
+			t.Errorf("Group.Do() = %v, want 7", r)
+		}
+	}
+
+	g.Do(context.Background(), "k", fn)
+	if calls.Load() != 2 {
+		t.Errorf("Group.Do() after completion should execute again, got %d", calls.Load())
+	}
+}
+
+// waitForWaiters blocks until n callers are waiting on the execution in flight for key.
+func waitForWaiters[K comparable, T any, E error](g *Group[K, T, E], key K, n int) {
+	for {
+		g.mu.Lock()
+		c := g.calls[key]
+		joined := c != nil && c.waiters == n
+		g.mu.Unlock()
+		if joined {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestGroupPanic(t *testing.T) {
+	var g Group[string, int, error]
+	boom := func(context.Context) Result[int, error] { panic("boom") }
+	if p := catchPanic(func() { g.Do(context.Background(), "k", boom) }); p != "boom" {
+		t.Errorf("Group.Do() should re-raise the panic in the caller, got %v", p)
+	}
+	if r := g.Do(context.Background(), "k", func(context.Context) Result[int, error] { return Ok[int, error](1) }); r.OrElse(0) != 1 {
+		t.Errorf("Group.Do() after a panic = %v, want a new execution", r)
+	}
+}
+
+func TestGroupWaiterCancel(t *testing.T) {
+	var g Group[string, int, error]
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var sawCancel atomic.Bool
+	fn := func(ctx context.Context) Result[int, error] {
+		close(started)
+		select {
+		case <-release:
+			return Ok[int, error](1)
+		case <-ctx.Done():
+			sawCancel.Store(true)
+			return Fail[int](ctx.Err())
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan Result[int, error], 1)
+	go func() { done <- g.Do(ctx, "k", fn) }()
+	<-started
+
+	other := make(chan Result[int, error], 1)
+	go func() { other <- g.Do(context.Background(), "k", fn) }()
+	waitForWaiters(&g, "k", 2)
+
+	cancel()
+	if r := <-done; !errors.Is(r.Unwrap(), context.Canceled) {
+		t.Errorf("Group.Do() for a canceled waiter = %v, want context.Canceled", r)
+	}
+	close(release)
+	if r := <-other; r.OrElse(0) != 1 || sawCancel.Load() {
+		t.Errorf("Group.Do() should keep the call running for remaining waiters, got %v", r)
+	}
+}
+
+func TestGroupAllWaitersLeave(t *testing.T) {
+	var g Group[string, int, error]
+	canceled := make(chan struct{})
+	fn := func(ctx context.Context) Result[int, error] {
+		<-ctx.Done()
+		close(canceled)
+		return Fail[int](ctx.Err())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	g.Do(ctx, "k", fn)
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Errorf("Group.Do() should cancel the call once every waiter has left")
+	}
+}
@@ -0,0 +1,155 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRateLimited is returned by RateLimit in GuardReject mode when no token is available.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrBulkheadFull is returned by Bulkhead in GuardReject mode when every slot is taken.
+	ErrBulkheadFull = errors.New("bulkhead full")
+)
+
+// GuardMode selects what RateLimit and Bulkhead do when they cannot admit a call right away.
+type GuardMode int
+
+const (
+	// GuardWait blocks until the call can be admitted or the context is done.
+	GuardWait GuardMode = iota
+	// GuardReject fails the call immediately.
+	GuardReject
+)
+
+// RateLimiter is a token bucket shared by the calls wrapped with RateLimit.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64   // Tokens added per second.
+	burst  float64   // The most tokens the bucket holds.
+	tokens float64   // Tokens available, negative while callers are waiting for reserved ones.
+	last   time.Time // When tokens was last brought up to date.
+}
+
+// NewRateLimiter creates a full token bucket refilled at rate tokens per second and holding at most burst tokens.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// refill adds the tokens earned since the last call. l.mu must be held.
+func (l *RateLimiter) refill(now time.Time) {
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// take takes a token if one is available now.
+func (l *RateLimiter) take() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// reserve takes a token, possibly one not earned yet, and returns how long to wait before using it.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	if l.rate <= 0 {
+		return math.MaxInt64
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// unreserve gives back a token taken by reserve but never used.
+func (l *RateLimiter) unreserve() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// RateLimit wraps fn so that every call first takes a token from l.
+// In GuardWait mode a call waits for its token; if ctx is done first, it fails with the context error.
+// In GuardReject mode a call without a token fails immediately with ErrRateLimited.
+// Both errors are converted into E with AdaptError.
+//
+// Example:
+//
+//	limiter := NewRateLimiter(50, 10)
+//	call := RateLimit(limiter, GuardReject, func(ctx context.Context) Result[Reply, error] {
+//	    return client.Call(ctx, req)
+//	})
+//	reply := call(ctx)
+func RateLimit[T any, E error](l *RateLimiter, mode GuardMode, fn func(context.Context) Result[T, E]) func(context.Context) Result[T, E] {
+	return func(ctx context.Context) Result[T, E] {
+		if err := ctx.Err(); err != nil {
+			return Fail[T, E](AdaptError[E](err))
+		}
+		if mode == GuardReject {
+			if !l.take() {
+				return Fail[T, E](AdaptError[E](ErrRateLimited))
+			}
+			return fn(ctx)
+		}
+
+		if delay := l.reserve(); delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				l.unreserve()
+				return Fail[T, E](AdaptError[E](ctx.Err()))
+			}
+		}
+		return fn(ctx)
+	}
+}
+
+// Semaphore bounds how many calls wrapped with Bulkhead run at once.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore creates a Semaphore with n slots.
+func NewSemaphore(n int) *Semaphore {
+	return &Semaphore{slots: make(chan struct{}, n)}
+}
+
+// Bulkhead wraps fn so that every call holds a slot of s while it runs.
+// In GuardWait mode a call waits for a free slot; if ctx is done first, it fails with the context error.
+// In GuardReject mode a call finding every slot taken fails immediately with ErrBulkheadFull.
+// Both errors are converted into E with AdaptError.
+func Bulkhead[T any, E error](s *Semaphore, mode GuardMode, fn func(context.Context) Result[T, E]) func(context.Context) Result[T, E] {
+	return func(ctx context.Context) Result[T, E] {
+		if err := ctx.Err(); err != nil {
+			return Fail[T, E](AdaptError[E](err))
+		}
+		if mode == GuardReject {
+			select {
+			case s.slots <- struct{}{}:
+			default:
+				return Fail[T, E](AdaptError[E](ErrBulkheadFull))
+			}
+		} else {
+			select {
+			case s.slots <- struct{}{}:
+			case <-ctx.Done():
+				return Fail[T, E](AdaptError[E](ctx.Err()))
+			}
+		}
+		defer func() { <-s.slots }()
+		return fn(ctx)
+	}
+}
@@ -0,0 +1,84 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func okCall(context.Context) Result[int, error] {
+	return Ok[int, error](1)
+}
+
+func TestRateLimitReject(t *testing.T) {
+	call := RateLimit(NewRateLimiter(1, 2), GuardReject, okCall)
+	for i := 0; i < 2; i++ {
+		if r := call(context.Background()); r.IsErr() {
+			t.Errorf("RateLimit() call %d within burst = %v, want success", i, r)
+		}
+	}
+	if r := call(context.Background()); !errors.Is(r.Unwrap(), ErrRateLimited) {
+		t.Errorf("RateLimit() beyond burst = %v, want ErrRateLimited", r)
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	call := RateLimit(NewRateLimiter(50, 1), GuardWait, okCall)
+	call(context.Background())
+
+	start := time.Now()
+	if r := call(context.Background()); r.IsErr() {
+		t.Errorf("RateLimit() in wait mode = %v, want success", r)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("RateLimit() in wait mode should wait for a token, waited %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	slow := RateLimit(NewRateLimiter(0.001, 1), GuardWait, okCall)
+	slow(ctx)
+	if r := slow(ctx); !errors.Is(r.Unwrap(), context.DeadlineExceeded) {
+		t.Errorf("RateLimit() waiting past the deadline = %v, want context.DeadlineExceeded", r)
+	}
+}
+
+func TestBulkhead(t *testing.T) {
+	sem := NewSemaphore(1)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	hold := Bulkhead(sem, GuardWait, func(context.Context) Result[int, error] {
+		close(started)
+		<-release
+		return Ok[int, error](1)
+	})
+	go hold(context.Background())
+	<-started
+
+	if r := Bulkhead(sem, GuardReject, okCall)(context.Background()); !errors.Is(r.Unwrap(), ErrBulkheadFull) {
+		t.Errorf("Bulkhead() when full = %v, want ErrBulkheadFull", r)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if r := Bulkhead(sem, GuardWait, okCall)(ctx); !errors.Is(r.Unwrap(), context.DeadlineExceeded) {
+		t.Errorf("Bulkhead() waiting past the deadline = %v, want context.DeadlineExceeded", r)
+	}
+
+	close(release)
+	if r := Bulkhead(sem, GuardWait, okCall)(context.Background()); r.IsErr() {
+		t.Errorf("Bulkhead() once a slot is free = %v, want success", r)
+	}
+}
+
+func TestGuardCustomError(t *testing.T) {
+	RegisterErrorAdapter(func(err error) *adaptTestError { return &adaptTestError{cause: err} })
+	call := RateLimit(NewRateLimiter(1, 0), GuardReject, func(context.Context) Result[int, *adaptTestError] {
+		return Ok[int, *adaptTestError](1)
+	})
+	r := call(context.Background())
+	if r.Unwrap() == nil || !errors.Is(r.Unwrap(), ErrRateLimited) {
+		t.Errorf("RateLimit() should adapt ErrRateLimited into a custom error type, got %v", r)
+	}
+}
@@ -0,0 +1,209 @@
+package tiny
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrInProgress is returned by Idempotent when another call with the same key has not finished yet.
+var ErrInProgress = errors.New("operation already in progress")
+
+// IdempotencyState is the state of an operation recorded in an IdempotencyStore.
+type IdempotencyState int
+
+const (
+	// IdempotencyInProgress marks an operation that has started but not finished.
+	IdempotencyInProgress IdempotencyState = iota + 1
+	// IdempotencySucceeded marks an operation that finished with a Success.
+	IdempotencySucceeded
+	// IdempotencyFailed marks an operation that finished with a Failure.
+	IdempotencyFailed
+)
+
+// IdempotencyRecord is what an IdempotencyStore keeps for a key.
+type IdempotencyRecord struct {
+	State  IdempotencyState `json:"state"`
+	Result json.RawMessage  `json:"result,omitempty"` // The JSON-encoded Result, once finished.
+}
+
+// IdempotencyStore records the state of operations by idempotency key.
+// Implementations must make Reserve atomic: of several concurrent calls for a new key, exactly one reserves it.
+type IdempotencyStore interface {
+	// Reserve records key as in progress and returns true, or returns the existing record and false if key is already known.
+	Reserve(key string) (IdempotencyRecord, bool, error)
+	// Complete replaces the record of a reserved key with its final record.
+	Complete(key string, rec IdempotencyRecord) error
+	// Release forgets a reserved key, so that the operation may be attempted again.
+	Release(key string) error
+}
+
+// Idempotent runs fn at most once for key and returns its Result, recording it in store.
+// A later call with the same key returns the recorded Result without running fn, as decoded by Result's UnmarshalJSON;
+// a call made while the first is still running fails with ErrInProgress.
+// If fn panics, the key is released so the operation can be retried, and the panic is re-raised.
+// If the Result cannot be encoded or recorded, the key stays in progress, so fn is never run twice,
+// and the encoding or store error is returned.
+// Store, encoding and ErrInProgress errors are converted into E with AdaptError.
+//
+// Example:
+//
+//	store := NewFileIdempotencyStore("/var/lib/payments/idempotency")
+//	receipt := Idempotent(store, req.IdempotencyKey, func() Result[Receipt, error] {
+//	    return charge(ctx, req)
+//	})
+func Idempotent[T any, E error](store IdempotencyStore, key string, fn func() Result[T, E]) Result[T, E] {
+	existing, reserved, err := store.Reserve(key)
+	if err != nil {
+		return Fail[T, E](AdaptError[E](err))
+	}
+	if !reserved {
+		if existing.State == IdempotencyInProgress {
+			return Fail[T, E](AdaptError[E](fmt.Errorf("%w: %s", ErrInProgress, key)))
+		}
+		var recorded Result[T, E]
+		if err := json.Unmarshal(existing.Result, &recorded); err != nil {
+			return Fail[T, E](AdaptError[E](fmt.Errorf("recorded result of %s: %w", key, err)))
+		}
+		return recorded
+	}
+
+	finished := false
+	defer func() {
+		if !finished {
+			_ = store.Release(key)
+		}
+	}()
+	r := fn().settle()
+	finished = true
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return Fail[T, E](AdaptError[E](fmt.Errorf("encode result of %s: %w", key, err)))
+	}
+	rec := IdempotencyRecord{State: IdempotencySucceeded, Result: data}
+	if r.state == Failure {
+		rec.State = IdempotencyFailed
+	}
+	if err := store.Complete(key, rec); err != nil {
+		return Fail[T, E](AdaptError[E](fmt.Errorf("record result of %s: %w", key, err)))
+	}
+	return r
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore kept in memory, for tests and single-process use.
+// The zero value is ready to use.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// Reserve records key as in progress unless it is already known.
+func (s *MemoryIdempotencyStore) Reserve(key string) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[key]; ok {
+		return rec, false, nil
+	}
+	if s.records == nil {
+		s.records = make(map[string]IdempotencyRecord)
+	}
+	s.records[key] = IdempotencyRecord{State: IdempotencyInProgress}
+	return IdempotencyRecord{}, true, nil
+}
+
+// Complete stores the final record for key.
+func (s *MemoryIdempotencyStore) Complete(key string, rec IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = rec
+	return nil
+}
+
+// Release forgets key.
+func (s *MemoryIdempotencyStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// FileIdempotencyStore is an IdempotencyStore keeping one JSON file per key in a directory,
+// so that records survive restarts and can be shared by processes on the same machine.
+type FileIdempotencyStore struct {
+	dir string
+}
+
+// NewFileIdempotencyStore creates a FileIdempotencyStore in dir, which is created on first use.
+func NewFileIdempotencyStore(dir string) *FileIdempotencyStore {
+	return &FileIdempotencyStore{dir: dir}
+}
+
+// path returns the file for key. Keys are hashed, so any string is a valid key.
+func (s *FileIdempotencyStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Reserve creates the file for key exclusively, or reads the existing one.
+// A file that cannot be decoded, because its creator is still writing it, is reported as in progress.
+func (s *FileIdempotencyStore) Reserve(key string) (IdempotencyRecord, bool, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	f, err := os.OpenFile(s.path(key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		data, err := os.ReadFile(s.path(key))
+		if err != nil {
+			return IdempotencyRecord{}, false, err
+		}
+		var rec IdempotencyRecord
+		if json.Unmarshal(data, &rec) != nil {
+			rec = IdempotencyRecord{State: IdempotencyInProgress}
+		}
+		return rec, false, nil
+	}
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	err = json.NewEncoder(f).Encode(IdempotencyRecord{State: IdempotencyInProgress})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return IdempotencyRecord{}, err == nil, err
+}
+
+// Complete replaces the file for key atomically.
+func (s *FileIdempotencyStore) Complete(key string, rec IdempotencyRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".record-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Release removes the file for key.
+func (s *FileIdempotencyStore) Release(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package tiny
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIdempotent(t *testing.T) {
+	stores := map[string]func(t *testing.T) IdempotencyStore{
+		"memory": func(*testing.T) IdempotencyStore { return &MemoryIdempotencyStore{} },
+		"file":   func(t *testing.T) IdempotencyStore { return NewFileIdempotencyStore(t.TempDir()) },
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			calls := 0
+			charge := func() Result[int, *jsonTestError] {
+				calls++
+				return Ok[int, *jsonTestError](100 * calls)
+			}
+			first := Idempotent(store, "pay-1", charge)
+			second := Idempotent(store, "pay-1", charge)
+			if calls != 1 || first.OrElse(0) != 100 || second.OrElse(0) != 100 {
+				t.Errorf("Idempotent() = %v then %v after %d calls, want the recorded 100 once", first, second, calls)
+			}
+
+			decline := func() Result[int, *jsonTestError] {
+				calls++
+				return Fail[int](&jsonTestError{Code: "E42", Reason: "declined"})
+			}
+			Idempotent(store, "pay-2", decline)
+			replayed := Idempotent(store, "pay-2", decline)
+			if calls != 2 || replayed.Unwrap() == nil || replayed.Unwrap().Code != "E42" {
+				t.Errorf("Idempotent() should replay a recorded failure, got %v after %d calls", replayed, calls)
+			}
+
+			inner := Result[int, error]{}
+			outer := Idempotent(store, "pay-3", func() Result[int, error] {
+				inner = Idempotent(store, "pay-3", func() Result[int, error] { return Ok[int, error](1) })
+				return Ok[int, error](2)
+			})
+			if !errors.Is(inner.Unwrap(), ErrInProgress) || outer.OrElse(0) != 2 {
+				t.Errorf("Idempotent() while in progress = %v, want ErrInProgress", inner)
+			}
+		})
+	}
+}
+
+func TestIdempotentPanicReleases(t *testing.T) {
+	store := &MemoryIdempotencyStore{}
+	func() {
+		defer func() { recover() }()
+		Idempotent(store, "k", func() Result[int, error] { panic("boom") })
+	}()
+
+	if r := Idempotent(store, "k", func() Result[int, error] { return Ok[int, error](1) }); r.OrElse(0) != 1 {
+		t.Errorf("Idempotent() after a panic should run again, got %v", r)
+	}
+}
+
+func TestIdempotentEncodeFailure(t *testing.T) {
+	store := &MemoryIdempotencyStore{}
+	calls := 0
+	fn := func() Result[func(), error] {
+		calls++
+		return Ok[func(), error](func() {})
+	}
+	for i := 0; i < 3; i++ {
+		if r := Idempotent(store, "k", fn); r.IsOk() {
+			t.Errorf("Idempotent() = %v, want the encoding or in-progress error", r)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Idempotent() ran fn %d times, want 1", calls)
+	}
+}
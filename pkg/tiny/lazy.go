@@ -0,0 +1,134 @@
+package tiny
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LazyOption configures a Lazy.
+type LazyOption func(*lazyOptions)
+
+type lazyOptions struct {
+	retryOnFailure bool          // Whether a Failure is discarded instead of cached.
+	ttl            time.Duration // How long a Success stays cached, zero for forever.
+	clock          Clock         // The clock measuring ttl.
+}
+
+// RetryOnFailure makes a Lazy discard a Failure instead of caching it, so the next Get runs the computation again.
+// Callers already waiting on the failed computation still receive its Failure.
+func RetryOnFailure() LazyOption {
+	return func(o *lazyOptions) {
+		o.retryOnFailure = true
+	}
+}
+
+// ExpireAfter makes a Lazy recompute a Success once ttl has passed since it was produced.
+// The ttl is measured with the system clock unless WithClock is given.
+func ExpireAfter(ttl time.Duration, opts ...TimeOption) LazyOption {
+	clock := clockOf(opts)
+	return func(o *lazyOptions) {
+		o.ttl = ttl
+		o.clock = clock
+	}
+}
+
+// Lazy is a Result computed on first use and shared by every caller.
+// However many goroutines call Get concurrently, the computation runs at most once at a time.
+// A Lazy must be created with NewLazy.
+type Lazy[T any, E error] struct {
+	fn   func(context.Context) Result[T, E]
+	opts lazyOptions
+
+	mu      sync.Mutex
+	cached  bool            // Whether result holds a reusable outcome.
+	result  Result[T, E]    // The cached outcome.
+	expires time.Time       // When result stops being reusable, if opts.ttl is set.
+	call    *lazyCall[T, E] // The computation in flight, if any.
+}
+
+// lazyCall is a single run of a Lazy's computation.
+type lazyCall[T any, E error] struct {
+	done   chan struct{} // Closed once result or panic is set.
+	result Result[T, E]
+	panic  any // The value fn panicked with, if it did.
+}
+
+// NewLazy creates a Lazy that computes its Result with fn.
+// By default the first outcome, Success or Failure, is cached forever.
+//
+// Example:
+//
+//	config := NewLazy(func(ctx context.Context) Result[*Config, error] {
+//	    return loadConfig(ctx)
+//	}, RetryOnFailure())
+//	cfg := config.Get(ctx)
+func NewLazy[T any, E error](fn func(context.Context) Result[T, E], opts ...LazyOption) *Lazy[T, E] {
+	l := &Lazy[T, E]{fn: fn, opts: lazyOptions{clock: SystemClock{}}}
+	for _, opt := range opts {
+		opt(&l.opts)
+	}
+	return l
+}
+
+// Get returns the cached Result, starting the computation if there is none and none is in flight.
+// The computation runs with a context carrying ctx's values but not its cancellation,
+// so a caller giving up does not fail the computation for everyone else.
+// If ctx is done before the Result is available, Get returns a Failure Result with the context error, converted into E with AdaptError.
+// If the computation panics, the panic is re-raised in every caller waiting on it, and nothing is cached.
+func (l *Lazy[T, E]) Get(ctx context.Context) Result[T, E] {
+	l.mu.Lock()
+	if l.cached && (l.opts.ttl == 0 || l.opts.clock.Now().Before(l.expires)) {
+		result := l.result
+		l.mu.Unlock()
+		return result
+	}
+	c := l.call
+	if c == nil {
+		c = &lazyCall[T, E]{done: make(chan struct{})}
+		l.call = c
+		go l.run(context.WithoutCancel(ctx), c)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.panic != nil {
+			panic(c.panic)
+		}
+		return c.result
+	case <-ctx.Done():
+		return Fail[T, E](AdaptError[E](ctx.Err()))
+	}
+}
+
+// Reset discards the cached Result, so the next Get computes it again.
+// A computation already in flight is not affected.
+func (l *Lazy[T, E]) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cached = false
+}
+
+// run computes the Result for c and caches it according to the options.
+// A panic is handed to the waiting callers and never cached.
+func (l *Lazy[T, E]) run(ctx context.Context, c *lazyCall[T, E]) {
+	defer close(c.done)
+	defer func() {
+		if c.panic = recover(); c.panic != nil {
+			l.mu.Lock()
+			l.call = nil
+			l.mu.Unlock()
+		}
+	}()
+	c.result = l.fn(ctx).settle()
+
+	l.mu.Lock()
+	l.call = nil
+	if c.result.state != Failure || !l.opts.retryOnFailure {
+		l.cached = true
+		l.result = c.result
+		l.expires = l.opts.clock.Now().Add(l.opts.ttl)
+	}
+	l.mu.Unlock()
+}
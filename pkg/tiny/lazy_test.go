@@ -0,0 +1,140 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLazyComputesOnce(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	l := NewLazy(func(context.Context) Result[int, error] {
+		calls.Add(1)
+		<-release
+		return Ok[int, error](42)
+	})
+
+	var wg sync.WaitGroup
+	results := make([]Result[int, error], 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = l.Get(context.Background())
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Lazy should compute once, got %d calls", calls.Load())
+	}
+	for _, r := range results {
+		if r.OrElse(0) != 42 {
+			t.Errorf("Lazy.Get() = %v, want 42", r)
+		}
+	}
+	if l.Get(context.Background()).OrElse(0) != 42 || calls.Load() != 1 {
+		t.Errorf("Lazy should reuse the cached Result, got %d calls", calls.Load())
+	}
+}
+
+func TestLazyFailure(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name      string
+		opts      []LazyOption
+		wantCalls int32
+	}{
+		{name: "cached by default", wantCalls: 1},
+		{name: "retried with RetryOnFailure", opts: []LazyOption{RetryOnFailure()}, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			l := NewLazy(func(context.Context) Result[int, error] {
+				calls.Add(1)
+				return Fail[int](errBoom)
+			}, tt.opts...)
+			for i := 0; i < 3; i++ {
+				if r := l.Get(context.Background()); !errors.Is(r.Unwrap(), errBoom) {
+					t.Errorf("Lazy.Get() = %v, want %v", r, errBoom)
+				}
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("Lazy computed %d times, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+// catchPanic runs fn and returns the value it panicked with, or nil.
+func catchPanic(fn func()) (p any) {
+	defer func() { p = recover() }()
+	fn()
+	return nil
+}
+
+func TestLazyPanic(t *testing.T) {
+	var calls atomic.Int32
+	l := NewLazy(func(context.Context) Result[int32, error] {
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		return Ok[int32, error](calls.Load())
+	})
+
+	if p := catchPanic(func() { l.Get(context.Background()) }); p != "boom" {
+		t.Errorf("Lazy.Get() should re-raise the panic in the caller, got %v", p)
+	}
+	if got := l.Get(context.Background()).OrElse(0); got != 2 {
+		t.Errorf("Lazy.Get() after a panic = %d, want a new computation", got)
+	}
+}
+
+func TestLazyExpireAfter(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	var calls atomic.Int32
+	l := NewLazy(func(context.Context) Result[int32, error] {
+		return Ok[int32, error](calls.Add(1))
+	}, ExpireAfter(10*time.Millisecond, WithClock(clock)))
+
+	if got := l.Get(context.Background()).OrElse(0); got != 1 {
+		t.Errorf("Lazy.Get() = %d, want 1", got)
+	}
+	clock.Advance(9 * time.Millisecond)
+	if got := l.Get(context.Background()).OrElse(0); got != 1 {
+		t.Errorf("Lazy.Get() before expiry = %d, want 1", got)
+	}
+	clock.Advance(time.Millisecond)
+	if got := l.Get(context.Background()).OrElse(0); got != 2 {
+		t.Errorf("Lazy.Get() after expiry = %d, want 2", got)
+	}
+}
+
+func TestLazyCallerCancel(t *testing.T) {
+	release := make(chan struct{})
+	l := NewLazy(func(ctx context.Context) Result[int, error] {
+		<-release
+		if err := ctx.Err(); err != nil {
+			return Fail[int](err)
+		}
+		return Ok[int, error](1)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if r := l.Get(ctx); !errors.Is(r.Unwrap(), context.Canceled) {
+		t.Errorf("Lazy.Get() with a canceled context = %v, want context.Canceled", r)
+	}
+	close(release)
+	if r := l.Get(context.Background()); r.OrElse(0) != 1 {
+		t.Errorf("Lazy.Get() after another caller gave up = %v, want 1", r)
+	}
+}
@@ -0,0 +1,131 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is matched by every NotFoundError.
+var ErrNotFound = errors.New("not found")
+
+// NotFoundError reports a key that a batch function left out of its response.
+type NotFoundError struct {
+	Key any
+}
+
+// Error names the missing key.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("key %v not found", e.Key)
+}
+
+// Unwrap returns ErrNotFound, so errors.Is(err, ErrNotFound) matches every NotFoundError.
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// DefaultLoaderWait is the batching window used when LoaderOptions.Wait is zero.
+const DefaultLoaderWait = time.Millisecond
+
+// LoaderOptions configures a Loader.
+type LoaderOptions struct {
+	Wait     time.Duration // How long a batch collects keys before it is dispatched. Zero means DefaultLoaderWait.
+	MaxBatch int           // The most keys in a batch; a full batch is dispatched at once. Zero means no bound.
+}
+
+// Loader gathers individual Load calls into batches, so that one batch function call serves many callers.
+// A Loader must be created with NewLoader.
+type Loader[K comparable, T any, E error] struct {
+	batchFn func(context.Context, []K) map[K]Result[T, E]
+	opts    LoaderOptions
+
+	mu    sync.Mutex
+	batch *loaderBatch[K, T, E] // The batch still collecting keys, if any.
+}
+
+// loaderBatch is a set of keys dispatched together.
+type loaderBatch[K comparable, T any, E error] struct {
+	ctx     context.Context    // The context batchFn runs with.
+	keys    []K                // The distinct keys requested, in order.
+	seen    map[K]struct{}     // The keys already in keys.
+	timer   *time.Timer        // Dispatches the batch when the window closes.
+	once    sync.Once          // Guards the single dispatch.
+	results map[K]Result[T, E] // The response of batchFn.
+	panic   any                // The value batchFn panicked with, if it did.
+	done    chan struct{}      // Closed once results or panic is set.
+}
+
+// NewLoader creates a Loader that resolves batches of keys with batchFn.
+// batchFn must return a Result for every key it can resolve; any key missing from its map
+// fails with a *NotFoundError converted into E with AdaptError.
+//
+// Example:
+//
+//	users := NewLoader(func(ctx context.Context, ids []string) map[string]Result[*User, error] {
+//	    return fetchUsers(ctx, ids)
+//	}, LoaderOptions{Wait: 2 * time.Millisecond, MaxBatch: 100})
+//	u := users.Load(ctx, id)
+func NewLoader[K comparable, T any, E error](batchFn func(context.Context, []K) map[K]Result[T, E], opts LoaderOptions) *Loader[K, T, E] {
+	if opts.Wait <= 0 {
+		opts.Wait = DefaultLoaderWait
+	}
+	return &Loader[K, T, E]{batchFn: batchFn, opts: opts}
+}
+
+// Load adds key to the current batch and waits for its Result.
+// The batch function runs with a context carrying the values of the first caller's ctx, but not its cancellation.
+// If ctx is done first, Load returns a Failure Result with the context error, converted into E with AdaptError.
+// If the batch function panics, the panic is re-raised in every caller waiting on the batch.
+func (l *Loader[K, T, E]) Load(ctx context.Context, key K) Result[T, E] {
+	l.mu.Lock()
+	b := l.batch
+	if b == nil {
+		b = &loaderBatch[K, T, E]{
+			ctx:  context.WithoutCancel(ctx),
+			seen: make(map[K]struct{}),
+			done: make(chan struct{}),
+		}
+		b.timer = time.AfterFunc(l.opts.Wait, func() { l.dispatch(b) })
+		l.batch = b
+	}
+	if _, ok := b.seen[key]; !ok {
+		b.seen[key] = struct{}{}
+		b.keys = append(b.keys, key)
+	}
+	if l.opts.MaxBatch > 0 && len(b.keys) >= l.opts.MaxBatch {
+		l.batch = nil
+		b.timer.Stop()
+		go l.dispatch(b)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		if b.panic != nil {
+			panic(b.panic)
+		}
+		if r, ok := b.results[key]; ok {
+			return r
+		}
+		return Fail[T, E](AdaptError[E](&NotFoundError{Key: key}))
+	case <-ctx.Done():
+		return Fail[T, E](AdaptError[E](ctx.Err()))
+	}
+}
+
+// dispatch closes b to new keys and resolves it with the batch function, at most once.
+func (l *Loader[K, T, E]) dispatch(b *loaderBatch[K, T, E]) {
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	b.once.Do(func() {
+		defer close(b.done)
+		defer func() { b.panic = recover() }()
+		b.results = l.batchFn(b.ctx, b.keys)
+	})
+}
@@ -0,0 +1,95 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (b *batchRecorder) fn(_ context.Context, keys []int) map[int]Result[string, error] {
+	b.mu.Lock()
+	b.batches = append(b.batches, append([]int(nil), keys...))
+	b.mu.Unlock()
+
+	results := make(map[int]Result[string, error], len(keys))
+	for _, k := range keys {
+		switch {
+		case k < 0:
+			results[k] = Fail[string](errors.New("negative key"))
+		case k < 100:
+			results[k] = Ok[string, error](string(rune('a' + k)))
+		}
+	}
+	return results
+}
+
+func loadAll(l *Loader[int, string, error], keys ...int) []Result[string, error] {
+	var wg sync.WaitGroup
+	results := make([]Result[string, error], len(keys))
+	for i, k := range keys {
+		wg.Add(1)
+		go func(i, k int) {
+			defer wg.Done()
+			results[i] = l.Load(context.Background(), k)
+		}(i, k)
+	}
+	wg.Wait()
+	return results
+}
+
+func TestLoaderBatches(t *testing.T) {
+	rec := &batchRecorder{}
+	l := NewLoader(rec.fn, LoaderOptions{Wait: 20 * time.Millisecond})
+	results := loadAll(l, 0, 1, 2, 1, -1, 500)
+
+	if len(rec.batches) != 1 || len(rec.batches[0]) != 5 {
+		t.Errorf("Loader should make one call with distinct keys, got %v", rec.batches)
+	}
+	for i, want := range []string{"a", "b", "c", "b"} {
+		if results[i].OrElse("") != want {
+			t.Errorf("Load(%d) = %v, want %q", i, results[i], want)
+		}
+	}
+	if results[4].IsOk() {
+		t.Errorf("Load(-1) should return the batch failure, got %v", results[4])
+	}
+	var nf *NotFoundError
+	if !errors.Is(results[5].Unwrap(), ErrNotFound) || !errors.As(results[5].Unwrap(), &nf) || nf.Key != 500 {
+		t.Errorf("Load(500) should fail with a NotFoundError, got %v", results[5])
+	}
+}
+
+func TestLoaderMaxBatch(t *testing.T) {
+	rec := &batchRecorder{}
+	l := NewLoader(rec.fn, LoaderOptions{Wait: time.Hour, MaxBatch: 2})
+	loadAll(l, 1, 2, 3, 4)
+
+	if len(rec.batches) != 2 {
+		t.Errorf("Loader should dispatch full batches without waiting, got %v", rec.batches)
+	}
+}
+
+func TestLoaderPanic(t *testing.T) {
+	l := NewLoader(func(context.Context, []int) map[int]Result[string, error] { panic("boom") }, LoaderOptions{MaxBatch: 1})
+	if p := catchPanic(func() { l.Load(context.Background(), 1) }); p != "boom" {
+		t.Errorf("Loader.Load() should re-raise the panic in the caller, got %v", p)
+	}
+}
+
+func TestLoaderCallerCancel(t *testing.T) {
+	rec := &batchRecorder{}
+	l := NewLoader(rec.fn, LoaderOptions{Wait: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if r := l.Load(ctx, 1); !errors.Is(r.Unwrap(), context.DeadlineExceeded) {
+		t.Errorf("Load() with an expired context = %v, want context.DeadlineExceeded", r)
+	}
+}
@@ -0,0 +1,183 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime/debug"
+	"time"
+)
+
+// Timeout fails a Call that takes longer than d with an "operation timed out" error.
+// If the caller's context is done first, its error is returned instead.
+// The Call runs with a context that is canceled once d has passed; a panic in it is re-raised on the caller's goroutine.
+// The timeout is measured with the system clock unless WithClock is given.
+func Timeout(d time.Duration, opts ...TimeOption) Middleware {
+	clock := clockOf(opts)
+	return func(next Call) Call {
+		return func(ctx context.Context) Result[any, error] {
+			timeoutCtx, cancel := clock.WithTimeout(ctx, d)
+			defer cancel()
+
+			type outcome struct {
+				result Result[any, error]
+				panic  any
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						done <- outcome{panic: p}
+					}
+				}()
+				done <- outcome{result: next(timeoutCtx)}
+			}()
+
+			select {
+			case out := <-done:
+				if out.panic != nil {
+					panic(out.panic)
+				}
+				return out.result
+			case <-timeoutCtx.Done():
+				err := timeoutCtx.Err()
+				if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+					err = fmt.Errorf("operation timed out after %v", d)
+				}
+				return Fail[any, error](err)
+			}
+		}
+	}
+}
+
+// Backoff returns how long to wait before the given retry, counting from 1.
+type Backoff func(retry int) time.Duration
+
+// ConstantBackoff waits d before every retry.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff waits base before the first retry and doubles the wait for each following one, up to max.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		d := float64(base) * math.Pow(2, float64(retry-1))
+		if d > float64(max) {
+			return max
+		}
+		return time.Duration(d)
+	}
+}
+
+// RetryOptions configures Retry.
+type RetryOptions struct {
+	Attempts int              // The most attempts, including the first. Values below 1 mean 1.
+	Backoff  Backoff          // The wait before each retry. Nil means no wait.
+	RetryIf  func(error) bool // Reports whether a failure is worth retrying. Nil means every failure is.
+	Clock    Clock            // The clock measuring the backoff. Nil means SystemClock.
+}
+
+// Retry calls next again after a Failure, until it succeeds, RetryIf rejects the error or the attempts run out.
+// It returns the last Result. If ctx is done while waiting between attempts, it returns the context error.
+func Retry(opts RetryOptions) Middleware {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+	return func(next Call) Call {
+		return func(ctx context.Context) Result[any, error] {
+			r := next(ctx).settle()
+			for retry := 1; retry < opts.Attempts && r.state == Failure; retry++ {
+				if opts.RetryIf != nil && !opts.RetryIf(r.fault) {
+					break
+				}
+				if opts.Backoff != nil {
+					timer := opts.Clock.NewTimer(opts.Backoff(retry))
+					select {
+					case <-timer.C():
+					case <-ctx.Done():
+						timer.Stop()
+						return Fail[any, error](ctx.Err())
+					}
+				}
+				if err := ctx.Err(); err != nil {
+					return Fail[any, error](err)
+				}
+				r = next(ctx).settle()
+			}
+			return r
+		}
+	}
+}
+
+// Logging logs every Call to logger under name: at debug level on Success and at error level on Failure,
+// with the elapsed time.
+func Logging(logger *slog.Logger, name string) Middleware {
+	return func(next Call) Call {
+		return func(ctx context.Context) Result[any, error] {
+			start := time.Now()
+			r := next(ctx).settle()
+			elapsed := time.Since(start)
+			if r.state == Failure {
+				logger.ErrorContext(ctx, "operation failed", "op", name, "elapsed", elapsed, "error", r.fault)
+			} else {
+				logger.DebugContext(ctx, "operation succeeded", "op", name, "elapsed", elapsed)
+			}
+			return r
+		}
+	}
+}
+
+// Metrics reports the elapsed time and error of every Call to observe. The error is nil on Success.
+func Metrics(observe func(elapsed time.Duration, err error)) Middleware {
+	return func(next Call) Call {
+		return func(ctx context.Context) Result[any, error] {
+			start := time.Now()
+			r := next(ctx)
+			observe(time.Since(start), r.Unwrap())
+			return r
+		}
+	}
+}
+
+// PanicError is the error Recover turns a panic into.
+type PanicError struct {
+	Value any    // The value passed to panic.
+	Stack []byte // The stack of the panicking goroutine.
+}
+
+// Error describes the panic value.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Recover turns a panic in a Call into a Failure Result holding a *PanicError.
+func Recover() Middleware {
+	return func(next Call) Call {
+		return func(ctx context.Context) (r Result[any, error]) {
+			defer func() {
+				if p := recover(); p != nil {
+					r = Fail[any, error](&PanicError{Value: p, Stack: debug.Stack()})
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
+
+// WithRateLimit guards every Call with RateLimit.
+func WithRateLimit(l *RateLimiter, mode GuardMode) Middleware {
+	return func(next Call) Call {
+		return RateLimit(l, mode, next)
+	}
+}
+
+// WithBulkhead guards every Call with Bulkhead.
+func WithBulkhead(s *Semaphore, mode GuardMode) Middleware {
+	return func(next Call) Call {
+		return Bulkhead(s, mode, next)
+	}
+}
@@ -0,0 +1,164 @@
+package tiny
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func countingCall(calls *int, failures int, err error) Call {
+	return func(context.Context) Result[any, error] {
+		*calls++
+		if *calls <= failures {
+			return Fail[any](err)
+		}
+		return Ok[any, error](*calls)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	fast := Timeout(50*time.Millisecond, WithClock(clock))(func(context.Context) Result[any, error] {
+		return Ok[any, error](1)
+	})
+	if r := fast(context.Background()); r.IsErr() {
+		t.Errorf("Timeout() on a fast call = %v, want success", r)
+	}
+
+	slow := Timeout(10*time.Millisecond, WithClock(clock))(func(ctx context.Context) Result[any, error] {
+		<-ctx.Done()
+		return Ok[any, error](1)
+	})
+	go func() {
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Millisecond)
+	}()
+	if r := slow(context.Background()); r.IsOk() || r.Unwrap().Error() != "operation timed out after 10ms" {
+		t.Errorf("Timeout() on a slow call = %v, want a timeout error", r)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if r := slow(canceled); !errors.Is(r.Unwrap(), context.Canceled) {
+		t.Errorf("Timeout() with a canceled context = %v, want context.Canceled", r)
+	}
+
+	panicking := Recover()(Timeout(time.Second)(func(context.Context) Result[any, error] {
+		panic("boom")
+	}))
+	var pe *PanicError
+	if r := panicking(context.Background()); !errors.As(r.Unwrap(), &pe) {
+		t.Errorf("Timeout() should re-raise a panic for Recover to catch, got %v", r)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+	tests := []struct {
+		name      string
+		opts      RetryOptions
+		failures  int
+		err       error
+		wantCalls int
+		wantOk    bool
+	}{
+		{name: "succeeds after retries", opts: RetryOptions{Attempts: 3}, failures: 2, err: errTemporary, wantCalls: 3, wantOk: true},
+		{name: "gives up", opts: RetryOptions{Attempts: 3}, failures: 5, err: errTemporary, wantCalls: 3},
+		{name: "no retry on success", opts: RetryOptions{Attempts: 3}, wantCalls: 1, wantOk: true},
+		{
+			name:      "retry filter",
+			opts:      RetryOptions{Attempts: 3, RetryIf: func(err error) bool { return !errors.Is(err, errPermanent) }},
+			failures:  5,
+			err:       errPermanent,
+			wantCalls: 1,
+		},
+		{name: "with backoff", opts: RetryOptions{Attempts: 2, Backoff: ConstantBackoff(0)}, failures: 1, err: errTemporary, wantCalls: 2, wantOk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			r := Retry(tt.opts)(countingCall(&calls, tt.failures, tt.err))(context.Background())
+			if calls != tt.wantCalls || r.IsOk() != tt.wantOk {
+				t.Errorf("Retry() = %v after %d calls, want ok %v after %d", r, calls, tt.wantOk, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	calls := 0
+	retry := Retry(RetryOptions{Attempts: 3, Backoff: ExponentialBackoff(time.Second, time.Minute), Clock: clock})
+	done := make(chan Result[any, error])
+	go func() {
+		done <- retry(countingCall(&calls, 2, errors.New("down")))(context.Background())
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Second)
+	if r := <-done; r.IsErr() || calls != 3 {
+		t.Errorf("Retry() = %v after %d calls, want success after 3", r, calls)
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	clock := NewFakeClock(time.Now())
+	go func() {
+		clock.BlockUntil(1)
+		cancel()
+	}()
+	calls := 0
+	r := Retry(RetryOptions{Attempts: 5, Backoff: ConstantBackoff(time.Hour), Clock: clock})(countingCall(&calls, 5, errors.New("down")))(ctx)
+	if calls != 1 || !errors.Is(r.Unwrap(), context.Canceled) {
+		t.Errorf("Retry() should stop waiting when the context is done, got %v after %d calls", r, calls)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	for retry, want := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 40 * time.Millisecond, 4: 50 * time.Millisecond} {
+		if got := b(retry); got != want {
+			t.Errorf("ExponentialBackoff()(%d) = %v, want %v", retry, got, want)
+		}
+	}
+}
+
+func TestLoggingAndMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var observed []error
+	calls := 0
+	call := Logging(logger, "fetch")(Metrics(func(_ time.Duration, err error) {
+		observed = append(observed, err)
+	})(countingCall(&calls, 1, errors.New("down"))))
+
+	call(context.Background())
+	call(context.Background())
+
+	if len(observed) != 2 || observed[0] == nil || observed[1] != nil {
+		t.Errorf("Metrics() observed %v, want a failure then a success", observed)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "operation failed") || !strings.Contains(out, "operation succeeded") || !strings.Contains(out, "op=fetch") {
+		t.Errorf("Logging() wrote %q", out)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	r := Recover()(func(context.Context) Result[any, error] {
+		panic("boom")
+	})(context.Background())
+	var pe *PanicError
+	if !errors.As(r.Unwrap(), &pe) || pe.Value != "boom" || len(pe.Stack) == 0 {
+		t.Errorf("Recover() = %v, want a PanicError", r)
+	}
+}
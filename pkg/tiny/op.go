@@ -0,0 +1,51 @@
+package tiny
+
+import "context"
+
+// Op is a context-aware operation from In to a Result of Out.
+type Op[In, Out any, E error] func(context.Context, In) Result[Out, E]
+
+// Call is a single invocation of an Op as a Middleware sees it: the input is already bound,
+// and the Result is erased to Result[any, error] so that one Middleware fits every Op.
+type Call func(context.Context) Result[any, error]
+
+// Middleware wraps a Call with a policy such as a timeout, retries or logging.
+type Middleware func(next Call) Call
+
+// Chain wraps op in mws, in the declared order: the first Middleware is the outermost,
+// so it sees every attempt made by the ones after it.
+// Errors produced by the middlewares themselves are converted into E with AdaptError.
+//
+// Example:
+//
+//	getUser := Chain(fetchUser,
+//	    Recover(),
+//	    Logging(logger, "getUser"),
+//	    Retry(RetryOptions{Attempts: 3, Backoff: ExponentialBackoff(50*time.Millisecond, time.Second)}),
+//	    Timeout(500*time.Millisecond),
+//	)
+//	u := getUser(ctx, id)
+func Chain[In, Out any, E error](op Op[In, Out, E], mws ...Middleware) Op[In, Out, E] {
+	return func(ctx context.Context, in In) Result[Out, E] {
+		var call Call = func(ctx context.Context) Result[any, error] {
+			r := op(ctx, in).settle()
+			if r.state == Failure {
+				return propagate[any, error](r, r.fault)
+			}
+			return Ok[any, error](r.value)
+		}
+		for i := len(mws) - 1; i >= 0; i-- {
+			call = mws[i](call)
+		}
+
+		r := call(ctx).settle()
+		if r.state == Failure {
+			return propagate[Out](r, AdaptError[E](r.fault))
+		}
+		var out Out
+		if r.value != nil {
+			out = r.value.(Out)
+		}
+		return Ok[Out, E](out)
+	}
+}
@@ -0,0 +1,60 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func tracing(trace *[]string, name string) Middleware {
+	return func(next Call) Call {
+		return func(ctx context.Context) Result[any, error] {
+			*trace = append(*trace, name+">")
+			r := next(ctx)
+			*trace = append(*trace, "<"+name)
+			return r
+		}
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var trace []string
+	op := Chain(func(_ context.Context, in int) Result[string, error] {
+		trace = append(trace, "op")
+		return Ok[string, error](strconv.Itoa(in))
+	}, tracing(&trace, "a"), tracing(&trace, "b"))
+
+	if got := op(context.Background(), 7); got.OrElse("") != "7" {
+		t.Errorf("Chain() = %v, want 7", got)
+	}
+	if got := strings.Join(trace, " "); got != "a> b> op <b <a" {
+		t.Errorf("Chain() should apply the first middleware outermost, got %q", got)
+	}
+}
+
+func TestChainErrors(t *testing.T) {
+	RegisterErrorAdapter(func(err error) *adaptTestError { return &adaptTestError{cause: err} })
+	own := &adaptTestError{cause: errors.New("own")}
+	failing := Chain(func(context.Context, int) Result[*int, *adaptTestError] {
+		return Fail[*int](own)
+	})
+	if got := failing(context.Background(), 1); got.Unwrap() != own {
+		t.Errorf("Chain() should return the op's own error unchanged, got %v", got)
+	}
+
+	rejected := Chain(func(context.Context, int) Result[*int, *adaptTestError] {
+		return Ok[*int, *adaptTestError](nil)
+	}, WithBulkhead(NewSemaphore(0), GuardReject))
+	if got := rejected(context.Background(), 1); !errors.Is(got.Unwrap(), ErrBulkheadFull) {
+		t.Errorf("Chain() should adapt middleware errors into E, got %v", got)
+	}
+
+	nilOk := Chain(func(context.Context, int) Result[*int, *adaptTestError] {
+		return Ok[*int, *adaptTestError](nil)
+	})
+	if got := nilOk(context.Background(), 1); got.IsErr() || got.OrElse(new(int)) != nil {
+		t.Errorf("Chain() should pass a nil value through, got %v", got)
+	}
+}
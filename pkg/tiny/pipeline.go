@@ -0,0 +1,145 @@
+package tiny
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// Pipeline is a durable chain of named steps, in the manner of Then, that checkpoints each step's output to a directory.
+// When a run is restarted, the steps whose output was saved are skipped and the run resumes from the first
+// step that failed or never ran. T must be encodable with encoding/json.
+// A Pipeline must be created with NewPipeline.
+type Pipeline[T any, E error] struct {
+	dir   string
+	steps []pipelineStep[T, E]
+	err   error // The first error found while adding steps.
+}
+
+// pipelineStep is a named step of a Pipeline.
+type pipelineStep[T any, E error] struct {
+	name string
+	fn   func(context.Context, T) Result[T, E]
+}
+
+// NewPipeline creates an empty Pipeline that keeps its checkpoints in dir.
+//
+// Example:
+//
+//	p := NewPipeline[Batch, error]("/var/lib/job/checkpoints").
+//	    Step("extract", extract).
+//	    Step("transform", transform).
+//	    Step("load", load)
+//	result := p.Run(ctx, Batch{Date: today})
+func NewPipeline[T any, E error](dir string) *Pipeline[T, E] {
+	return &Pipeline[T, E]{dir: dir}
+}
+
+// Step appends a step named name to p and returns p.
+// Names identify checkpoints across runs, so they must be unique and stable.
+func (p *Pipeline[T, E]) Step(name string, fn func(context.Context, T) Result[T, E]) *Pipeline[T, E] {
+	for _, s := range p.steps {
+		if s.name == name && p.err == nil {
+			p.err = fmt.Errorf("duplicate pipeline step %s", name)
+		}
+	}
+	p.steps = append(p.steps, pipelineStep[T, E]{name: name, fn: fn})
+	return p
+}
+
+// Run runs the steps in order, feeding each the output of the previous one, starting from input.
+// A step with a saved checkpoint is skipped and its saved output is used instead.
+// Each successful output is saved before the next step runs; a Failure stops the run and is not saved.
+// Errors reading or writing checkpoints, and the context error if ctx is done between steps,
+// are converted into E with AdaptError.
+// Checkpoints are not tied to input: call Reset before running the pipeline on different input.
+func (p *Pipeline[T, E]) Run(ctx context.Context, input T) Result[T, E] {
+	if p.err != nil {
+		return Fail[T, E](AdaptError[E](p.err))
+	}
+	current := Ok[T, E](input)
+	for i, step := range p.steps {
+		saved, found, err := p.load(i)
+		if err != nil {
+			return Fail[T, E](AdaptError[E](err))
+		}
+		if found {
+			current = Ok[T, E](saved)
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return Fail[T, E](AdaptError[E](err))
+		}
+		current = current.Then(func(v T) Result[T, E] {
+			return step.fn(ctx, v)
+		}).settle()
+		if current.state == Failure {
+			return current
+		}
+		if err := p.save(i, current); err != nil {
+			return Fail[T, E](AdaptError[E](err))
+		}
+	}
+	return current
+}
+
+// Reset removes every checkpoint, so the next Run starts from the first step.
+func (p *Pipeline[T, E]) Reset() error {
+	var errs []error
+	for i := range p.steps {
+		if err := os.Remove(p.path(i)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// path returns the checkpoint file of step i. The index is part of the name, so reordering steps invalidates their checkpoints.
+func (p *Pipeline[T, E]) path(i int) string {
+	return filepath.Join(p.dir, fmt.Sprintf("%03d-%s.json", i, url.PathEscape(p.steps[i].name)))
+}
+
+// load reads the checkpoint of step i, reporting false if there is none.
+func (p *Pipeline[T, E]) load(i int) (T, bool, error) {
+	var saved Result[T, E]
+	data, err := os.ReadFile(p.path(i))
+	if errors.Is(err, fs.ErrNotExist) {
+		return saved.value, false, nil
+	}
+	if err != nil {
+		return saved.value, false, err
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return saved.value, false, fmt.Errorf("checkpoint %s: %w", p.steps[i].name, err)
+	}
+	return saved.value, saved.state == Success, nil
+}
+
+// save writes the checkpoint of step i atomically, so that a crash never leaves a partial file behind.
+func (p *Pipeline[T, E]) save(i int, r Result[T, E]) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("checkpoint %s: %w", p.steps[i].name, err)
+	}
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(p.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p.path(i))
+}
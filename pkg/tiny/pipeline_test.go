@@ -0,0 +1,68 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPipelineResume(t *testing.T) {
+	dir := t.TempDir()
+	var ran []string
+	failLoad := true
+	build := func() *Pipeline[[]string, error] {
+		step := func(name string) func(context.Context, []string) Result[[]string, error] {
+			return func(_ context.Context, acc []string) Result[[]string, error] {
+				ran = append(ran, name)
+				if name == "load" && failLoad {
+					return Fail[[]string](errors.New("warehouse down"))
+				}
+				return Ok[[]string, error](append(acc, name))
+			}
+		}
+		return NewPipeline[[]string, error](dir).
+			Step("extract", step("extract")).
+			Step("transform", step("transform")).
+			Step("load", step("load"))
+	}
+
+	first := build().Run(context.Background(), nil)
+	if first.IsOk() || strings.Join(ran, " ") != "extract transform load" {
+		t.Fatalf("first Run() = %v after %v, want a failure at load", first, ran)
+	}
+
+	ran = nil
+	failLoad = false
+	second := build().Run(context.Background(), nil)
+	if strings.Join(ran, " ") != "load" {
+		t.Errorf("second Run() ran %v, want only load", ran)
+	}
+	if got := strings.Join(second.OrElse(nil), " "); got != "extract transform load" {
+		t.Errorf("second Run() = %v, want the checkpointed outputs carried through", second)
+	}
+
+	ran = nil
+	p := build()
+	if err := p.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	p.Run(context.Background(), nil)
+	if strings.Join(ran, " ") != "extract transform load" {
+		t.Errorf("Run() after Reset() ran %v, want every step", ran)
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	noop := func(_ context.Context, v int) Result[int, error] { return Ok[int, error](v) }
+	dup := NewPipeline[int, error](t.TempDir()).Step("a", noop).Step("a", noop)
+	if r := dup.Run(context.Background(), 1); r.IsOk() {
+		t.Errorf("Run() with duplicate step names should fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if r := NewPipeline[int, error](t.TempDir()).Step("a", noop).Run(ctx, 1); !errors.Is(r.Unwrap(), context.Canceled) {
+		t.Errorf("Run() with a canceled context = %v, want context.Canceled", r)
+	}
+}
@@ -0,0 +1,73 @@
+package tiny
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Recorder receives metrics about operations: the outcome and latency of every call of an Op wrapped by Instrument,
+// and the number of async calls started by the AsyncThen functions that have not delivered their Result yet.
+// Implementations must be safe for concurrent use. The tinymetrics package provides an expvar and an in-memory Recorder.
+type Recorder interface {
+	// Observe records one finished call of the operation name, with its latency and whether it succeeded.
+	Observe(name string, elapsed time.Duration, ok bool)
+	// InFlight adds delta, 1 or -1, to the number of async calls of name in flight.
+	InFlight(name string, delta int)
+}
+
+// recorderHolder boxes a Recorder so that it can be stored in an atomic.Pointer.
+type recorderHolder struct {
+	r Recorder
+}
+
+// recorder is the Recorder set with SetRecorder, nil if none is.
+var recorder atomic.Pointer[recorderHolder]
+
+// SetRecorder makes r receive the metrics of the whole program, replacing any previous Recorder.
+// A nil r turns metrics off, which is the default; without a Recorder, recording costs one atomic load.
+func SetRecorder(r Recorder) {
+	if r == nil {
+		recorder.Store(nil)
+		return
+	}
+	recorder.Store(&recorderHolder{r: r})
+}
+
+// currentRecorder returns the Recorder set with SetRecorder, or nil.
+func currentRecorder() Recorder {
+	if h := recorder.Load(); h != nil {
+		return h.r
+	}
+	return nil
+}
+
+// Instrument wraps op so that every call reports its outcome and latency under name to the Recorder set with SetRecorder.
+// An Unset Result counts as a Failure.
+//
+// Example:
+//
+//	tiny.SetRecorder(tinymetrics.NewExpvarRecorder("tiny", nil))
+//	getUser := tiny.Instrument("getUser", fetchUser)
+func Instrument[In, Out any, E error](name string, op Op[In, Out, E]) Op[In, Out, E] {
+	return func(ctx context.Context, in In) Result[Out, E] {
+		rec := currentRecorder()
+		if rec == nil {
+			return op(ctx, in)
+		}
+		start := time.Now()
+		r := op(ctx, in)
+		rec.Observe(name, time.Since(start), r.IsOk())
+		return r
+	}
+}
+
+// trackAsync counts an async call of name as in flight, and returns the function that counts it as done.
+func trackAsync(name string) (done func()) {
+	rec := currentRecorder()
+	if rec == nil {
+		return func() {}
+	}
+	rec.InFlight(name, 1)
+	return func() { rec.InFlight(name, -1) }
+}
@@ -0,0 +1,103 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testRecorder is a Recorder counting outcomes and in-flight calls per name.
+type testRecorder struct {
+	mu       sync.Mutex
+	ok, fail map[string]int
+	inFlight map[string]int
+	peak     map[string]int
+}
+
+func newTestRecorder(t *testing.T) *testRecorder {
+	rec := &testRecorder{ok: map[string]int{}, fail: map[string]int{}, inFlight: map[string]int{}, peak: map[string]int{}}
+	SetRecorder(rec)
+	t.Cleanup(func() { SetRecorder(nil) })
+	return rec
+}
+
+func (r *testRecorder) Observe(name string, _ time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ok {
+		r.ok[name]++
+	} else {
+		r.fail[name]++
+	}
+}
+
+func (r *testRecorder) InFlight(name string, delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight[name] += delta
+	r.peak[name] = max(r.peak[name], r.inFlight[name])
+}
+
+func (r *testRecorder) counts(name string) (ok, fail, inFlight, peak int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ok[name], r.fail[name], r.inFlight[name], r.peak[name]
+}
+
+func TestInstrument(t *testing.T) {
+	op := Instrument("half", func(_ context.Context, n int) Result[int, error] {
+		switch {
+		case n < 0:
+			return Result[int, error]{}
+		case n%2 != 0:
+			return Fail[int](errors.New("odd"))
+		}
+		return Ok[int, error](n / 2)
+	})
+
+	if got := op(context.Background(), 4); got.OrElse(0) != 2 {
+		t.Errorf("Instrument() without a Recorder = %v, want the Op's Result", got)
+	}
+
+	rec := newTestRecorder(t)
+	for _, n := range []int{2, 4, 3, -1} {
+		op(context.Background(), n)
+	}
+	if ok, fail, _, _ := rec.counts("half"); ok != 2 || fail != 2 {
+		t.Errorf("Instrument() recorded %d ok and %d fail, want 2 and 2", ok, fail)
+	}
+}
+
+func TestAsyncInFlight(t *testing.T) {
+	rec := newTestRecorder(t)
+	release := make(chan struct{})
+	slow := func(x int) Result[int, error] {
+		<-release
+		return Ok[int, error](x)
+	}
+	ctx := context.Background()
+	clock := NewFakeClock(time.Now())
+
+	channels := map[string]<-chan Result[int, error]{
+		"AsyncThen":                      AsyncThen(Ok[int, error](1), slow),
+		"AsyncThenWithTimeout":           AsyncThenWithTimeout(Ok[int, error](1), slow, time.Second, WithClock(clock)),
+		"AsyncThenWithContext":           AsyncThenWithContext(ctx, Ok[int, error](1), slow),
+		"AsyncThenWithContextAndTimeout": AsyncThenWithContextAndTimeout(ctx, Ok[int, error](1), slow, time.Second, WithClock(clock)),
+	}
+	for name := range channels {
+		if _, _, inFlight, _ := rec.counts(name); inFlight != 1 {
+			t.Errorf("%s in flight = %d while running, want 1", name, inFlight)
+		}
+	}
+
+	close(release)
+	for name, ch := range channels {
+		for range ch {
+		}
+		if _, _, inFlight, peak := rec.counts(name); inFlight != 0 || peak != 1 {
+			t.Errorf("%s in flight = %d (peak %d) once done, want 0 (peak 1)", name, inFlight, peak)
+		}
+	}
+}
@@ -3,25 +3,35 @@ package tiny
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"time"
 )
 
-// State represents the status of a Result, either Success or Failure.
+// State represents the status of a Result: Success or Failure, or Unset for the zero Result.
 type State int
 
 const (
+	// Unset is the state of the zero Result, which was never created with Ok or Fail.
+	// Every combinator treats it as a Failure of ErrUnset.
+	Unset State = iota
 	// Success indicates a successful operation.
-	Success State = iota
+	Success
 	// Failure indicates a failed operation.
 	Failure
 )
 
+// ErrUnset is the error of the zero Result, such as a Result struct field that was never initialised.
+// It is converted into E with AdaptError, which panics if E cannot represent it.
+var ErrUnset = errors.New("tiny: use of an unset Result")
+
 // Result encapsulates the outcome of an operation, holding either a value or an error.
 // T is the type of the successful value, and E is the type of the error.
+// The zero Result is Unset rather than a Success: create Results with Ok or Fail.
 type Result[T any, E error] struct {
-	state State // The state of the result (Success or Failure).
-	value T     // The value in case of success.
-	fault E     // The error in case of failure.
+	state State  // The state of the result (Unset, Success or Failure).
+	value T      // The value in case of success.
+	fault E      // The error in case of failure.
+	stack *stack // Where the Failure was created, if stack traces were captured.
 }
 
 // Ok creates a Result with a successful value.
@@ -32,14 +42,84 @@ func Ok[T any, E error](value T) Result[T, E] {
 
 // Fail creates a Result with an error.
 // It returns a Result in the Failure state with the provided error.
+// If EnableStackTraces is on, the caller's stack is recorded.
 func Fail[T any, E error](err E) Result[T, E] {
-	return Result[T, E]{state: Failure, fault: err}
+	r := Result[T, E]{state: Failure, fault: err}
+	if stackTraces.Load() {
+		r.stack = captureStack(0)
+	}
+	return r
+}
+
+// From creates a Result from a value and an error, as returned by most Go functions:
+// a Failure if err is an error, and a Success holding value otherwise.
+//
+// err is no error if it is nil, including a typed nil such as a nil *MyError, whether E is the pointer
+// type itself or an interface holding it. An error of a type that cannot be nil, such as a struct or an
+// integer code, is always a Failure, even its zero value: context.DeadlineExceeded is an empty struct.
+// Map, MapWithContext and the other functions bridging (T, E) returns follow the same rule.
+//
+// Example:
+//
+//	f := From(os.Open(path))
+func From[T any, E error](value T, err E) Result[T, E] {
+	return from(value, err, 1)
+}
+
+// from is From with the captured stack starting skip frames further up. From, Map and MapWithContext
+// pass 1 to leave out from itself, so that the stack starts at their caller.
+func from[T any, E error](value T, err E, skip int) Result[T, E] {
+	if isNilError(err) {
+		return Ok[T, E](value)
+	}
+	r := Result[T, E]{state: Failure, fault: err}
+	if stackTraces.Load() {
+		r.stack = captureStack(skip)
+	}
+	return r
+}
+
+// isNilError reports whether err means no error under the rule documented on From.
+func isNilError[E error](err E) bool {
+	if any(err) == nil {
+		return true
+	}
+	v := reflect.ValueOf(err)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// IsOk reports whether the Result is in the Success state.
+func (r Result[T, E]) IsOk() bool {
+	return r.state == Success
+}
+
+// IsErr reports whether the Result is in the Failure state, or Unset, which is treated as a Failure.
+func (r Result[T, E]) IsErr() bool {
+	return r.state != Success
+}
+
+// IsValid reports whether the Result was created with Ok or Fail, rather than being the zero Result.
+func (r Result[T, E]) IsValid() bool {
+	return r.state != Unset
+}
+
+// settle returns r, or, for an Unset Result, a Failure of ErrUnset converted into E with AdaptError.
+func (r Result[T, E]) settle() Result[T, E] {
+	if r.state == Unset {
+		return Result[T, E]{state: Failure, fault: AdaptError[E](ErrUnset)}
+	}
+	return r
 }
 
 // Then applies a function to the value of a successful Result.
 // If the Result is in the Failure state, it returns itself unchanged.
 // Otherwise, it applies fn to the value and returns the new Result.
 func (r Result[T, E]) Then(fn func(T) Result[T, E]) Result[T, E] {
+	r = r.settle()
 	if r.state == Failure {
 		return r
 	}
@@ -49,16 +129,14 @@ func (r Result[T, E]) Then(fn func(T) Result[T, E]) Result[T, E] {
 // Map transforms a Result's value using a function that may fail.
 // If the Result is in the Failure state, it returns a new Failure Result with the original error.
 // Otherwise, it applies fn to the value, returning a new Result with the transformed value or error.
+// Whether fn returned an error is decided as in From, so a typed nil error is a Success.
 func Map[T, U any, E error](r Result[T, E], fn func(T) (U, E)) Result[U, E] {
+	r = r.settle()
 	if r.state == Failure {
-		return Fail[U, E](r.fault)
-	}
-	val, err := fn(r.value)
-	// Use type assertion to check if err is nil.
-	if any(err) != nil && !errors.Is(err, nil) {
-		return Fail[U, E](err)
+		return propagate[U](r, r.fault)
 	}
-	return Ok[U, E](val)
+	v, err := fn(r.value)
+	return from(v, err, 1)
 }
 
 // OrElse returns the value of a successful Result or a default value if it failed.
@@ -74,11 +152,17 @@ func (r Result[T, E]) OrElse(defaultVal T) T {
 // Wrap wraps the error of a failed Result with additional context.
 // If the Result is in the Failure state, it returns a new Result with the error wrapped in a formatted message.
 // If the Result is in the Success state, it returns a new Result with the original value and an error type.
+// The Failure keeps its stack; if it has none and EnableStackTraces is on, the caller's stack is recorded.
 func (r Result[T, E]) Wrap(msg string) Result[T, error] {
+	r = r.settle()
 	if r.state == Failure {
 		// Convert E to error interface for wrapping.
 		wrappedErr := fmt.Errorf("%s: %w", msg, r.fault)
-		return Fail[T, error](wrappedErr)
+		wrapped := propagate[T, error](r, wrappedErr)
+		if wrapped.stack == nil && stackTraces.Load() {
+			wrapped.stack = captureStack(0)
+		}
+		return wrapped
 	}
 	return Ok[T, error](r.value)
 }
@@ -87,6 +171,7 @@ func (r Result[T, E]) Wrap(msg string) Result[T, error] {
 // If the Result is in the Failure state, it returns the encapsulated error.
 // Otherwise, it returns the zero value of type E.
 func (r Result[T, E]) Unwrap() E {
+	r = r.settle()
 	if r.state == Failure {
 		return r.fault
 	}
@@ -101,8 +186,9 @@ func (r Result[T, E]) Unwrap() E {
 func All[T any, E error](results ...Result[T, E]) Result[[]T, E] {
 	values := make([]T, 0, len(results))
 	for _, r := range results {
+		r = r.settle()
 		if r.state == Failure {
-			return Fail[[]T, E](r.fault)
+			return propagate[[]T](r, r.fault)
 		}
 		values = append(values, r.value)
 	}
@@ -113,6 +199,9 @@ func All[T any, E error](results ...Result[T, E]) Result[[]T, E] {
 // If the Result is in the Success state, it returns the encapsulated value.
 // If the Result is in the Failure state, it panics with a message containing the error.
 func (r Result[T, E]) UnwrapOrPanic() T {
+	if r.state == Unset {
+		panic("called UnwrapOrPanic on an unset Result")
+	}
 	if r.state == Failure {
 		panic(fmt.Sprintf("called UnwrapOrPanic on a Failure: %v", r.fault))
 	}
@@ -123,8 +212,9 @@ func (r Result[T, E]) UnwrapOrPanic() T {
 // If the Result is in the Failure state, it applies fn to the error and returns a new Result.
 // If the Result is in the Success state, it returns a new Result with the original value and transformed error type.
 func MapErr[T any, E, F error](r Result[T, E], fn func(E) F) Result[T, F] {
+	r = r.settle()
 	if r.state == Failure {
-		return Fail[T, F](fn(r.fault))
+		return propagate[T](r, fn(r.fault))
 	}
 	return Ok[T, F](r.value)
 }
@@ -133,9 +223,11 @@ func MapErr[T any, E, F error](r Result[T, E], fn func(E) F) Result[T, F] {
 // It returns a channel that will receive the Result of applying fn to the value.
 // If the Result is in the Failure state, the channel receives the original Result.
 func AsyncThen[T any, E error](r Result[T, E], fn func(T) Result[T, E]) <-chan Result[T, E] {
+	done := trackAsync("AsyncThen")
 	ch := make(chan Result[T, E], 1)
 	go func() {
 		defer close(ch)
+		defer done() // Before close, so a receiver that sees the channel closed sees the call done.
 		ch <- r.Then(fn)
 	}()
 	return ch
@@ -143,8 +235,11 @@ func AsyncThen[T any, E error](r Result[T, E], fn func(T) Result[T, E]) <-chan R
 
 // String returns a string representation of the Result.
 // For a Success state, it returns "Ok(value)".
-// For a Failure state, it returns "Err(fault)".
+// For a Failure state, it returns "Err(fault)", and for an Unset Result, "Unset".
 func (r Result[T, E]) String() string {
+	if r.state == Unset {
+		return "Unset"
+	}
 	if r.state == Success {
 		return fmt.Sprintf("Ok(%v)", r.value)
 	}
@@ -154,11 +249,16 @@ func (r Result[T, E]) String() string {
 // AsyncThenWithTimeout applies a function to a successful Result asynchronously with a timeout.
 // It returns a channel that will receive the Result of applying fn to the value or a timeout error.
 // If the Result is in the Failure state, the channel receives the original Result immediately.
-// If the operation exceeds the timeout, it returns a Failure Result with a timeout error.
-func AsyncThenWithTimeout[T any, E error](r Result[T, E], fn func(T) Result[T, E], timeout time.Duration) <-chan Result[T, E] {
+// If the operation exceeds the timeout, it returns a Failure Result with a timeout error, converted into E with AdaptError.
+// The timeout is measured with the system clock unless WithClock is given.
+func AsyncThenWithTimeout[T any, E error](r Result[T, E], fn func(T) Result[T, E], timeout time.Duration, opts ...TimeOption) <-chan Result[T, E] {
+	timer := clockOf(opts).NewTimer(timeout)
+	done := trackAsync("AsyncThenWithTimeout")
 	ch := make(chan Result[T, E], 1)
 	go func() {
 		defer close(ch)
+		defer done()
+		defer timer.Stop()
 
 		resultChan := make(chan Result[T, E], 1)
 		go func() {
@@ -168,9 +268,9 @@ func AsyncThenWithTimeout[T any, E error](r Result[T, E], fn func(T) Result[T, E
 		select {
 		case result := <-resultChan:
 			ch <- result
-		case <-time.After(timeout):
+		case <-timer.C():
 			err := fmt.Errorf("operation timed out after %v", timeout)
-			ch <- Fail[T, E](any(err).(E))
+			ch <- Fail[T, E](AdaptError[E](err))
 		}
 	}()
 	return ch
@@ -9,6 +9,7 @@ import (
 
 // ThenWithContext applies a function to the value of a successful Result, respecting the provided context.
 // If the context is canceled or times out before or during the function execution, it returns a Failure Result with the context error.
+// The context error is converted into E with AdaptError.
 // If the Result is in the Failure state, it returns itself unchanged.
 // Otherwise, it applies fn to the value and returns the new Result.
 //
@@ -19,20 +20,23 @@ import (
 //	    return Ok[string, error](s + " world")
 //	})
 func ThenWithContext[T any, E error](ctx context.Context, r Result[T, E], fn func(T) Result[T, E]) Result[T, E] {
+	r = r.settle()
 	if r.state == Failure {
 		return r
 	}
 	// Check if context is already canceled before proceeding.
 	if err := ctx.Err(); err != nil {
-		return Fail[T, E](any(err).(E))
+		return Fail[T, E](AdaptError[E](err))
 	}
 	return fn(r.value)
 }
 
 // MapWithContext transforms a Result's value using a function that may fail, respecting the provided context.
 // If the context is canceled or times out before or during the function execution, it returns a Failure Result with the context error.
+// The context error is converted into E with AdaptError.
 // If the Result is in the Failure state, it returns a new Failure Result with the original error.
 // Otherwise, it applies fn to the value, returning a new Result with the transformed value or error.
+// Whether fn returned an error is decided as in From, so a typed nil error is a Success.
 //
 // Example:
 //
@@ -41,23 +45,22 @@ func ThenWithContext[T any, E error](ctx context.Context, r Result[T, E], fn fun
 //	    return fmt.Sprintf("%d", i), nil
 //	})
 func MapWithContext[T, U any, E error](ctx context.Context, r Result[T, E], fn func(T) (U, E)) Result[U, E] {
+	r = r.settle()
 	if r.state == Failure {
-		return Fail[U, E](r.fault)
+		return propagate[U](r, r.fault)
 	}
 	// Check if context is already canceled before proceeding.
 	if err := ctx.Err(); err != nil {
-		return Fail[U, E](any(err).(E))
+		return Fail[U, E](AdaptError[E](err))
 	}
-	val, err := fn(r.value)
-	if any(err) != nil && !errors.Is(err, nil) {
-		return Fail[U, E](err)
-	}
-	return Ok[U, E](val)
+	v, err := fn(r.value)
+	return from(v, err, 1)
 }
 
 // AsyncThenWithContext applies a function to a successful Result asynchronously, respecting the provided context.
 // It returns a channel that will receive the Result of applying fn to the value.
 // If the context is canceled or times out, the channel receives a Failure Result with the context error.
+// The context error is converted into E with AdaptError.
 // If the Result is in the Failure state, the channel receives the original Result immediately.
 //
 // Example:
@@ -68,12 +71,14 @@ func MapWithContext[T, U any, E error](ctx context.Context, r Result[T, E], fn f
 //	})
 //	result := <-ch
 func AsyncThenWithContext[T any, E error](ctx context.Context, r Result[T, E], fn func(T) Result[T, E]) <-chan Result[T, E] {
+	done := trackAsync("AsyncThenWithContext")
 	ch := make(chan Result[T, E], 1)
 	go func() {
 		defer close(ch)
+		defer done()
 		// Check context before proceeding.
 		if err := ctx.Err(); err != nil {
-			ch <- Fail[T, E](any(err).(E))
+			ch <- Fail[T, E](AdaptError[E](err))
 			return
 		}
 		// Use a select to handle context cancellation during execution.
@@ -85,7 +90,7 @@ func AsyncThenWithContext[T any, E error](ctx context.Context, r Result[T, E], f
 		case result := <-resultChan:
 			ch <- result
 		case <-ctx.Done():
-			ch <- Fail[T, E](any(ctx.Err()).(E))
+			ch <- Fail[T, E](AdaptError[E](ctx.Err()))
 		}
 	}()
 	return ch
@@ -97,6 +102,9 @@ func AsyncThenWithContext[T any, E error](ctx context.Context, r Result[T, E], f
 // If the Result is in the Failure state, the channel receives the original Result immediately.
 //
 // The timeout parameter acts as an additional constraint beyond the context's deadline, whichever comes first.
+// An exceeded timeout fails with an "operation timed out" error, and an exceeded context deadline with context.DeadlineExceeded.
+// The context or timeout error is converted into E with AdaptError.
+// The timeout is measured with the system clock unless WithClock is given.
 //
 // Example:
 //
@@ -108,17 +116,20 @@ func AsyncThenWithContext[T any, E error](ctx context.Context, r Result[T, E], f
 //	    return Ok[string, error](s + " completed")
 //	}, 500*time.Millisecond)
 //	result := <-ch
-func AsyncThenWithContextAndTimeout[T any, E error](ctx context.Context, r Result[T, E], fn func(T) Result[T, E], timeout time.Duration) <-chan Result[T, E] {
+func AsyncThenWithContextAndTimeout[T any, E error](ctx context.Context, r Result[T, E], fn func(T) Result[T, E], timeout time.Duration, opts ...TimeOption) <-chan Result[T, E] {
+	clock := clockOf(opts)
+	done := trackAsync("AsyncThenWithContextAndTimeout")
 	ch := make(chan Result[T, E], 1)
 	go func() {
 		defer close(ch)
+		defer done()
 		// Check context before proceeding.
 		if err := ctx.Err(); err != nil {
-			ch <- Fail[T, E](any(err).(E))
+			ch <- Fail[T, E](AdaptError[E](err))
 			return
 		}
 		// Create a context with timeout if it's stricter than the provided context's deadline.
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
+		ctxWithTimeout, cancel := clock.WithTimeout(ctx, timeout)
 		defer cancel()
 
 		resultChan := make(chan Result[T, E], 1)
@@ -131,10 +142,10 @@ func AsyncThenWithContextAndTimeout[T any, E error](ctx context.Context, r Resul
 			ch <- result
 		case <-ctxWithTimeout.Done():
 			err := ctxWithTimeout.Err()
-			if errors.Is(err, context.DeadlineExceeded) {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
 				err = fmt.Errorf("operation timed out after %v", timeout)
 			}
-			ch <- Fail[T, E](any(err).(E))
+			ch <- Fail[T, E](AdaptError[E](err))
 		}
 	}()
 	return ch
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"testing"
 	"time"
 )
@@ -172,8 +171,9 @@ func TestAsyncThenWithContext(t *testing.T) {
 		{
 			name: "context canceled during execution",
 			ctx: func() context.Context {
-				ctx, _ := context.WithTimeout(context.Background(), 10*time.Millisecond)
-				return ctx // Removed defer cancel() to avoid premature cancellation
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				t.Cleanup(cancel)
+				return ctx
 			}(),
 			input: Ok[string, error]("start"),
 			fn: func(s string) Result[string, error] {
@@ -208,21 +208,29 @@ func TestAsyncThenWithContext(t *testing.T) {
 }
 
 func TestAsyncThenWithContextAndTimeout(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	slow := func(s string) Result[string, error] {
+		<-release
+		return Ok[string, error](s + " late")
+	}
+
 	tests := []struct {
 		name    string
-		ctx     context.Context
+		ctx     func(clock *FakeClock) context.Context
 		input   Result[string, error]
 		fn      func(string) Result[string, error]
 		timeout time.Duration
+		timers  int           // The timers pending once the call is waiting.
+		advance time.Duration // How far to move the clock then.
 		want    Result[string, error]
 		wantErr bool
 	}{
 		{
 			name:  "success case",
-			ctx:   context.Background(),
+			ctx:   func(*FakeClock) context.Context { return context.Background() },
 			input: Ok[string, error]("start"),
 			fn: func(s string) Result[string, error] {
-				time.Sleep(50 * time.Millisecond)
 				return Ok[string, error](s + " done")
 			},
 			timeout: 100 * time.Millisecond,
@@ -231,7 +239,7 @@ func TestAsyncThenWithContextAndTimeout(t *testing.T) {
 		},
 		{
 			name:    "failure case",
-			ctx:     context.Background(),
+			ctx:     func(*FakeClock) context.Context { return context.Background() },
 			input:   Fail[string, error](errors.New("failed")),
 			fn:      func(s string) Result[string, error] { return Ok[string, error](s) },
 			timeout: 100 * time.Millisecond,
@@ -239,8 +247,12 @@ func TestAsyncThenWithContextAndTimeout(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "context canceled before start",
-			ctx:     func() context.Context { ctx, cancel := context.WithCancel(context.Background()); cancel(); return ctx }(),
+			name: "context canceled before start",
+			ctx: func(*FakeClock) context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
 			input:   Ok[string, error]("start"),
 			fn:      func(s string) Result[string, error] { return Ok[string, error](s) },
 			timeout: 100 * time.Millisecond,
@@ -249,28 +261,27 @@ func TestAsyncThenWithContextAndTimeout(t *testing.T) {
 		},
 		{
 			name: "context deadline before timeout",
-			ctx: func() context.Context {
-				ctx, _ := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			ctx: func(clock *FakeClock) context.Context {
+				ctx, cancel := clock.WithTimeout(context.Background(), 10*time.Millisecond)
+				t.Cleanup(cancel)
 				return ctx
-			}(),
-			input: Ok[string, error]("start"),
-			fn: func(s string) Result[string, error] {
-				time.Sleep(50 * time.Millisecond) // Longer than context timeout
-				return Ok[string, error](s + " late")
 			},
+			input:   Ok[string, error]("start"),
+			fn:      slow,
 			timeout: 100 * time.Millisecond,
+			timers:  2,
+			advance: 10 * time.Millisecond,
 			want:    Fail[string, error](context.DeadlineExceeded),
 			wantErr: true,
 		},
 		{
-			name:  "timeout before context deadline",
-			ctx:   context.Background(),
-			input: Ok[string, error]("start"),
-			fn: func(s string) Result[string, error] {
-				time.Sleep(100 * time.Millisecond) // Longer than timeout
-				return Ok[string, error](s + " late")
-			},
+			name:    "timeout before context deadline",
+			ctx:     func(*FakeClock) context.Context { return context.Background() },
+			input:   Ok[string, error]("start"),
+			fn:      slow,
 			timeout: 50 * time.Millisecond,
+			timers:  1,
+			advance: 50 * time.Millisecond,
 			want:    Fail[string, error](fmt.Errorf("operation timed out after %v", 50*time.Millisecond)),
 			wantErr: true,
 		},
@@ -278,7 +289,13 @@ func TestAsyncThenWithContextAndTimeout(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ch := AsyncThenWithContextAndTimeout(tt.ctx, tt.input, tt.fn, tt.timeout)
+			clock := NewFakeClock(time.Now())
+			ctx := tt.ctx(clock)
+			ch := AsyncThenWithContextAndTimeout(ctx, tt.input, tt.fn, tt.timeout, WithClock(clock))
+			if tt.advance > 0 {
+				clock.BlockUntil(tt.timers)
+				clock.Advance(tt.advance)
+			}
 			got := <-ch
 			if tt.wantErr && got.state != Failure {
 				t.Errorf("AsyncThenWithContextAndTimeout() expected failure, got %v", got)
@@ -288,15 +305,8 @@ func TestAsyncThenWithContextAndTimeout(t *testing.T) {
 				t.Errorf("AsyncThenWithContextAndTimeout() expected success, got %v", got)
 				return
 			}
-			if tt.wantErr {
-				if tt.name == "timeout before context deadline" {
-					// Special case for timeout error since the error message includes a dynamic duration
-					if !strings.Contains(got.fault.Error(), "operation timed out after") {
-						t.Errorf("AsyncThenWithContextAndTimeout() error = %v, want error containing 'operation timed out after'", got.fault)
-					}
-				} else if got.fault.Error() != tt.want.fault.Error() {
-					t.Errorf("AsyncThenWithContextAndTimeout() error = %v, want %v", got.fault, tt.want.fault)
-				}
+			if tt.wantErr && got.fault.Error() != tt.want.fault.Error() {
+				t.Errorf("AsyncThenWithContextAndTimeout() error = %v, want %v", got.fault, tt.want.fault)
 			}
 			if !tt.wantErr && got.value != tt.want.value {
 				t.Errorf("AsyncThenWithContextAndTimeout() = %v, want %v", got.value, tt.want.value)
@@ -0,0 +1,93 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// StackTracer is implemented by errors that carry the stack of their creation.
+// %+v prints the frames of every error of the chain that implements it.
+type StackTracer interface {
+	StackTrace() []runtime.Frame
+}
+
+// Format implements fmt.Formatter.
+// %v and %s print Ok(value), Err(error) or Unset, like String; other verbs and their flags apply to the value or error inside.
+// %+v on a Failure prints the error followed by its whole chain, one error per line with its type,
+// the stack frames of every error implementing StackTracer, and the stack captured by the Failure itself, if any. %#v prints the Result as Go syntax, like GoString.
+//
+// Example:
+//
+//	r := Fail[int](fmt.Errorf("load config: %w", err))
+//	fmt.Printf("%+v\n", r)
+//	// Err(load config: open app.yaml: no such file or directory)
+//	//     *fmt.wrapError: load config: open app.yaml: no such file or directory
+//	//     *fs.PathError: open app.yaml: no such file or directory
+//	//     syscall.Errno: no such file or directory
+func (r Result[T, E]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, r.GoString())
+		return
+	}
+	if r.state == Unset {
+		io.WriteString(f, "Unset")
+		return
+	}
+	if r.state == Success {
+		fmt.Fprintf(f, "Ok(%s)", fmt.Sprintf(fmt.FormatString(f, verb), r.value))
+		return
+	}
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "Err(%v)", r.fault)
+		writeChain(f, r.fault, 1)
+		if frames := r.StackTrace(); frames != nil {
+			io.WriteString(f, "\n    failed at:")
+			writeFrames(f, frames, "    ")
+		}
+		return
+	}
+	fmt.Fprintf(f, "Err(%s)", fmt.Sprintf(fmt.FormatString(f, verb), r.fault))
+}
+
+// GoString implements fmt.GoStringer, printing the Result as the Go expression that creates it,
+// such as tiny.Ok[int, error](42).
+func (r Result[T, E]) GoString() string {
+	types := reflect.TypeFor[T]().String() + ", " + reflect.TypeFor[E]().String()
+	if r.state == Unset {
+		return fmt.Sprintf("tiny.Result[%s]{}", types)
+	}
+	if r.state == Success {
+		return fmt.Sprintf("tiny.Ok[%s](%#v)", types, r.value)
+	}
+	return fmt.Sprintf("tiny.Fail[%s](%#v)", types, r.fault)
+}
+
+// writeChain writes err and the errors it wraps, one per line indented by depth, with their stack frames.
+// Errors joining several others list each branch one level deeper.
+func writeChain(w io.Writer, err error, depth int) {
+	for err != nil {
+		indent := strings.Repeat("    ", depth)
+		fmt.Fprintf(w, "\n%s%T: %v", indent, err, err)
+		if st, ok := err.(StackTracer); ok {
+			writeFrames(w, st.StackTrace(), indent)
+		}
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range multi.Unwrap() {
+				writeChain(w, e, depth+1)
+			}
+			return
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// writeFrames writes frames one level deeper than indent, in the layout of a goroutine trace.
+func writeFrames(w io.Writer, frames []runtime.Frame, indent string) {
+	for _, frame := range frames {
+		fmt.Fprintf(w, "\n%s    %s\n%s        %s:%d", indent, frame.Function, indent, frame.File, frame.Line)
+	}
+}
@@ -0,0 +1,83 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+type stackTestError struct{ msg string }
+
+func (e *stackTestError) Error() string { return e.msg }
+
+func (e *stackTestError) StackTrace() []runtime.Frame {
+	return []runtime.Frame{{Function: "app.load", File: "/src/app/load.go", Line: 12}}
+}
+
+func ExampleResult_Format() {
+	base := errors.New("connection refused")
+	r := Fail[int](fmt.Errorf("fetch user: %w", base))
+	fmt.Printf("%v\n", r)
+	fmt.Printf("%+v\n", r)
+	// Output:
+	// Err(fetch user: connection refused)
+	// Err(fetch user: connection refused)
+	//     *fmt.wrapError: fetch user: connection refused
+	//     *errors.errorString: connection refused
+}
+
+func TestFormat(t *testing.T) {
+	boom := errors.New("boom")
+	tests := []struct {
+		name   string
+		format string
+		r      fmt.Formatter
+		want   string
+	}{
+		{name: "ok %v", format: "%v", r: Ok[int, error](42), want: "Ok(42)"},
+		{name: "ok %s", format: "%s", r: Ok[string, error]("hi"), want: "Ok(hi)"},
+		{name: "ok %q", format: "%q", r: Ok[string, error]("hi"), want: `Ok("hi")`},
+		{name: "ok %05d", format: "%05d", r: Ok[int, error](42), want: "Ok(00042)"},
+		{name: "ok %+v", format: "%+v", r: Ok[struct{ A int }, error](struct{ A int }{1}), want: "Ok({A:1})"},
+		{name: "err %v", format: "%v", r: Fail[int](boom), want: "Err(boom)"},
+		{name: "err %q", format: "%q", r: Fail[int](boom), want: `Err("boom")`},
+		{name: "err %+v plain", format: "%+v", r: Fail[int](boom), want: "Err(boom)\n    *errors.errorString: boom"},
+		{
+			name:   "err %+v wrapped twice",
+			format: "%+v",
+			r:      Fail[int](boom).Wrap("parse").Wrap("load"),
+			want:   "Err(load: parse: boom)\n    *fmt.wrapError: load: parse: boom\n    *fmt.wrapError: parse: boom\n    *errors.errorString: boom",
+		},
+		{
+			name:   "err %+v joined",
+			format: "%+v",
+			r:      Fail[int](errors.Join(boom, fmt.Errorf("b: %w", boom))),
+			want:   "Err(boom\nb: boom)\n    *errors.joinError: boom\nb: boom\n        *errors.errorString: boom\n        *fmt.wrapError: b: boom\n        *errors.errorString: boom",
+		},
+		{
+			name:   "err %+v with stack",
+			format: "%+v",
+			r:      Fail[int](fmt.Errorf("wrap: %w", &stackTestError{msg: "traced"})),
+			want:   "Err(wrap: traced)\n    *fmt.wrapError: wrap: traced\n    *tiny.stackTestError: traced\n        app.load\n            /src/app/load.go:12",
+		},
+		{name: "ok %#v", format: "%#v", r: Ok[string, error]("hi"), want: `tiny.Ok[string, error]("hi")`},
+		{name: "err %#v", format: "%#v", r: Fail[int](boom), want: `tiny.Fail[int, error](&errors.errorString{s:"boom"})`},
+		{name: "concrete err %#v", format: "%#v", r: Fail[int](&jsonTestError{Code: "x"}), want: `tiny.Fail[int, *tiny.jsonTestError](&tiny.jsonTestError{Code:"x", Reason:""})`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.r); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoString(t *testing.T) {
+	r := Ok[[]int, error]([]int{1, 2})
+	if got, want := r.GoString(), "tiny.Ok[[]int, error]([]int{1, 2})"; got != want {
+		t.Errorf("GoString() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package tiny
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DecodedError stands in for a decoded error whose original type cannot be restored,
+// because the error type of the Result is an interface such as error.
+type DecodedError struct {
+	Message string `json:"message"`
+}
+
+// Error returns the message of the original error.
+func (e *DecodedError) Error() string {
+	return e.Message
+}
+
+// MarshalJSON encodes a Success as {"ok": value} and a Failure as {"err": error}.
+// If E is an interface type, such as error, the error is encoded as its message,
+// and decodes back into a *DecodedError. Otherwise the error itself is encoded with encoding/json,
+// so a concrete E should have exported fields or implement json.Marshaler to round-trip faithfully.
+// An Unset Result cannot be encoded and returns ErrUnset.
+func (r Result[T, E]) MarshalJSON() ([]byte, error) {
+	if r.state == Unset {
+		return nil, ErrUnset
+	}
+	if r.state == Failure {
+		var fault any = r.fault
+		if reflect.TypeFor[E]().Kind() == reflect.Interface {
+			fault = errorMessage(r.fault)
+		}
+		return json.Marshal(map[string]any{"err": fault})
+	}
+	return json.Marshal(map[string]any{"ok": r.value})
+}
+
+// UnmarshalJSON decodes a Result encoded by MarshalJSON.
+func (r *Result[T, E]) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if raw, ok := fields["ok"]; ok {
+		var value T
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		*r = Ok[T, E](value)
+		return nil
+	}
+	raw, ok := fields["err"]
+	if !ok {
+		return errors.New("tiny: Result JSON has neither ok nor err")
+	}
+	var fault E
+	if reflect.TypeFor[E]().Kind() == reflect.Interface {
+		var msg string
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return err
+		}
+		decoded, ok := any(&DecodedError{Message: msg}).(E)
+		if !ok {
+			return fmt.Errorf("tiny: cannot decode an error into %v", reflect.TypeFor[E]())
+		}
+		fault = decoded
+	} else if err := json.Unmarshal(raw, &fault); err != nil {
+		return err
+	}
+	*r = Fail[T, E](fault)
+	return nil
+}
+
+// errorMessage returns err.Error(), or an empty string for a nil error.
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
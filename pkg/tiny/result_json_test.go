@@ -0,0 +1,68 @@
+package tiny
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type jsonTestError struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+func (e *jsonTestError) Error() string { return e.Code + ": " + e.Reason }
+
+func TestResultJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input Result[[]int, error]
+		want  string
+	}{
+		{name: "success", input: Ok[[]int, error]([]int{1, 2}), want: `{"ok":[1,2]}`},
+		{name: "nil success", input: Ok[[]int, error](nil), want: `{"ok":null}`},
+		{name: "failure", input: Fail[[]int](errors.New("boom")), want: `{"err":"boom"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.input)
+			if err != nil || string(data) != tt.want {
+				t.Fatalf("Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+			var got Result[[]int, error]
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got.String() != tt.input.String() {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.input)
+			}
+		})
+	}
+}
+
+func TestResultJSONConcreteError(t *testing.T) {
+	in := Fail[int](&jsonTestError{Code: "E42", Reason: "declined"})
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var out Result[int, *jsonTestError]
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := out.Unwrap(); got == nil || *got != *in.Unwrap() {
+		t.Errorf("Unmarshal() = %v, want %v", out, in)
+	}
+
+	var decoded Result[int, error]
+	json.Unmarshal([]byte(`{"err":"boom"}`), &decoded)
+	var de *DecodedError
+	if !errors.As(decoded.Unwrap(), &de) || de.Message != "boom" {
+		t.Errorf("Unmarshal() into an error interface should give a DecodedError, got %v", decoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{}`), &decoded); err == nil {
+		t.Errorf("Unmarshal() of an empty object should fail")
+	}
+}
@@ -1,8 +1,10 @@
 package tiny
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -20,7 +22,7 @@ func ExampleFail() {
 	// Output: Err(something went wrong)
 }
 
-func ExampleThen() {
+func ExampleResult_Then() {
 	r := Ok[int, error](5)
 	result := r.Then(func(x int) Result[int, error] {
 		return Ok[int, error](x * 2)
@@ -38,7 +40,7 @@ func ExampleMap() {
 	// Output: value: 5
 }
 
-func ExampleOrElse() {
+func ExampleResult_OrElse() {
 	r1 := Ok[int, error](42)
 	fmt.Println(r1.OrElse(0))
 
@@ -50,7 +52,7 @@ func ExampleOrElse() {
 	// 0
 }
 
-func ExampleWrap() {
+func ExampleResult_Wrap() {
 	err := errors.New("original error")
 	r := Fail[int, error](err)
 	wrapped := r.Wrap("context")
@@ -202,25 +204,28 @@ func TestAsyncThen(t *testing.T) {
 }
 
 func TestAsyncThenWithTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Now())
 	r1 := Ok[int, error](5)
 	ch1 := AsyncThenWithTimeout(r1, func(x int) Result[int, error] {
-		time.Sleep(50 * time.Millisecond)
 		return Ok[int, error](x * 2)
-	}, 100*time.Millisecond)
+	}, 100*time.Millisecond, WithClock(clock))
 
 	result1 := <-ch1
 	if result1.UnwrapOrPanic() != 10 {
 		t.Errorf("AsyncThenWithTimeout should multiply by 2, got %v", result1.value)
 	}
 
+	release := make(chan struct{})
+	defer close(release)
 	ch2 := AsyncThenWithTimeout(r1, func(x int) Result[int, error] {
-		time.Sleep(100 * time.Millisecond)
+		<-release
 		return Ok[int, error](x * 2)
-	}, 50*time.Millisecond)
+	}, 50*time.Millisecond, WithClock(clock))
 
+	clock.Advance(50 * time.Millisecond)
 	result2 := <-ch2
-	if result2.state != Failure {
-		t.Errorf("AsyncThenWithTimeout should fail on timeout")
+	if result2.state != Failure || result2.fault.Error() != "operation timed out after 50ms" {
+		t.Errorf("AsyncThenWithTimeout should fail on timeout, got %v", result2)
 	}
 }
 
@@ -255,3 +260,179 @@ func TestString(t *testing.T) {
 		t.Errorf("String should return formatted error, got %v", r2.String())
 	}
 }
+
+func TestUnset(t *testing.T) {
+	var unset Result[int, error]
+	double := func(x int) Result[int, error] { return Ok[int, error](x * 2) }
+	ctx := context.Background()
+	clock := NewFakeClock(time.Now())
+	use := func(context.Context, int) Result[int, error] { panic("use called on an unset resource") }
+	release := func(int) error { panic("release called on an unset resource") }
+	acquire := func(context.Context) Result[int, error] { return unset }
+
+	tests := []struct {
+		name string
+		got  func() Result[int, error]
+	}{
+		{name: "Then", got: func() Result[int, error] { return unset.Then(double) }},
+		{name: "Map", got: func() Result[int, error] {
+			return Map(unset, func(x int) (int, error) { return x, nil })
+		}},
+		{name: "MapErr", got: func() Result[int, error] {
+			return MapErr(unset, func(e error) error { return e })
+		}},
+		{name: "Wrap", got: func() Result[int, error] { return unset.Wrap("load") }},
+		{name: "All", got: func() Result[int, error] {
+			return Map(All(Ok[int, error](1), unset), func(v []int) (int, error) { return len(v), nil })
+		}},
+		{name: "ThenWithContext", got: func() Result[int, error] { return ThenWithContext(ctx, unset, double) }},
+		{name: "MapWithContext", got: func() Result[int, error] {
+			return MapWithContext(ctx, unset, func(x int) (int, error) { return x, nil })
+		}},
+		{name: "AsyncThen", got: func() Result[int, error] { return <-AsyncThen(unset, double) }},
+		{name: "AsyncThenWithTimeout", got: func() Result[int, error] {
+			return <-AsyncThenWithTimeout(unset, double, time.Second, WithClock(clock))
+		}},
+		{name: "AsyncThenWithContext", got: func() Result[int, error] { return <-AsyncThenWithContext(ctx, unset, double) }},
+		{name: "AsyncThenWithContextAndTimeout", got: func() Result[int, error] {
+			return <-AsyncThenWithContextAndTimeout(ctx, unset, double, time.Second, WithClock(clock))
+		}},
+		{name: "Using", got: func() Result[int, error] {
+			return Using(func() Result[int, error] { return unset }, func(x int) Result[int, error] { return use(ctx, x) }, release)
+		}},
+		{name: "UsingWithContext", got: func() Result[int, error] { return UsingWithContext(ctx, acquire, use, release) }},
+		{name: "AsyncUsingWithContext", got: func() Result[int, error] { return <-AsyncUsingWithContext(ctx, acquire, use, release) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.got()
+			if !got.IsValid() || !got.IsErr() || !errors.Is(got.Unwrap(), ErrUnset) {
+				t.Errorf("%s on an unset Result = %v, want a Failure of ErrUnset", tt.name, got)
+			}
+		})
+	}
+}
+
+func TestUnsetAccessors(t *testing.T) {
+	var unset Result[int, error]
+	if unset.IsValid() || unset.IsOk() || !unset.IsErr() {
+		t.Errorf("zero Result: IsValid %v, IsOk %v, IsErr %v, want false, false, true", unset.IsValid(), unset.IsOk(), unset.IsErr())
+	}
+	if !Ok[int, error](0).IsValid() || !Fail[int](errors.New("x")).IsValid() {
+		t.Errorf("IsValid() should be true for Ok and Fail")
+	}
+	if got := unset.OrElse(7); got != 7 {
+		t.Errorf("OrElse() on an unset Result = %d, want the default", got)
+	}
+	if !errors.Is(unset.Unwrap(), ErrUnset) {
+		t.Errorf("Unwrap() on an unset Result = %v, want ErrUnset", unset.Unwrap())
+	}
+	if got := unset.String(); got != "Unset" {
+		t.Errorf("String() = %q, want Unset", got)
+	}
+	if _, err := unset.MarshalJSON(); !errors.Is(err, ErrUnset) {
+		t.Errorf("MarshalJSON() error = %v, want ErrUnset", err)
+	}
+
+	defer func() {
+		if p := recover(); p != "called UnwrapOrPanic on an unset Result" {
+			t.Errorf("UnwrapOrPanic() panicked with %v", p)
+		}
+	}()
+	unset.UnwrapOrPanic()
+}
+
+func TestUnsetWithoutAdapter(t *testing.T) {
+	defer func() {
+		if p := recover(); p == nil || !strings.Contains(fmt.Sprint(p), "register one with RegisterErrorAdapter") {
+			t.Errorf("Then() on an unset Result with an unadaptable E panicked with %v, want a clear message", p)
+		}
+	}()
+	var unset Result[int, *jsonTestError]
+	unset.Then(func(x int) Result[int, *jsonTestError] { return Ok[int, *jsonTestError](x) })
+}
+
+type ptrError struct{ msg string }
+
+func (e *ptrError) Error() string { return e.msg }
+
+type codeError int
+
+func (e codeError) Error() string { return fmt.Sprintf("code %d", int(e)) }
+
+type structError struct{ Msg string }
+
+func (e structError) Error() string { return e.Msg }
+
+type emptyError struct{}
+
+func (emptyError) Error() string { return "empty" }
+
+func TestFrom(t *testing.T) {
+	var nilPtr *ptrError
+	tests := []struct {
+		name   string
+		got    func() Result[int, error]
+		wantOk bool
+	}{
+		{name: "nil interface", got: func() Result[int, error] { return From[int, error](1, nil) }, wantOk: true},
+		{name: "interface error", got: func() Result[int, error] { return From(1, errors.New("x")) }},
+		{name: "interface holding a typed nil", got: func() Result[int, error] { return From[int, error](1, nilPtr) }, wantOk: true},
+		{name: "interface holding a pointer", got: func() Result[int, error] { return From[int, error](1, &ptrError{"x"}) }},
+		{name: "nil pointer", got: func() Result[int, error] { return From(1, nilPtr).Wrap("") }, wantOk: true},
+		{name: "pointer", got: func() Result[int, error] { return From(1, &ptrError{"x"}).Wrap("") }},
+		{name: "zero value error", got: func() Result[int, error] { return From(1, codeError(0)).Wrap("") }},
+		{name: "value error", got: func() Result[int, error] { return From(1, codeError(3)).Wrap("") }},
+		{name: "zero struct error", got: func() Result[int, error] { return From(1, structError{}).Wrap("") }},
+		{name: "struct error", got: func() Result[int, error] { return From(1, structError{Msg: "x"}).Wrap("") }},
+		{name: "empty struct error", got: func() Result[int, error] { return From(1, emptyError{}).Wrap("") }},
+		{name: "context.DeadlineExceeded", got: func() Result[int, error] { return From(1, context.DeadlineExceeded) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.got()
+			if got.IsOk() != tt.wantOk {
+				t.Errorf("From() = %v, want ok %v", got, tt.wantOk)
+			}
+			if tt.wantOk && got.OrElse(0) != 1 {
+				t.Errorf("From() = %v, want the value", got)
+			}
+		})
+	}
+}
+
+func TestMapTypedNil(t *testing.T) {
+	var nilPtr *ptrError
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		fn     func(int) (int, error)
+		wantOk bool
+	}{
+		{name: "nil", fn: func(x int) (int, error) { return x, nil }, wantOk: true},
+		{name: "typed nil", fn: func(x int) (int, error) { return x, nilPtr }, wantOk: true},
+		{name: "pointer", fn: func(x int) (int, error) { return x, &ptrError{"x"} }},
+		{name: "context.DeadlineExceeded", fn: func(x int) (int, error) { return 0, context.DeadlineExceeded }},
+		{name: "empty struct", fn: func(x int) (int, error) { return 0, emptyError{} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Map(Ok[int, error](1), tt.fn); got.IsOk() != tt.wantOk {
+				t.Errorf("Map() = %v, want ok %v", got, tt.wantOk)
+			}
+			if got := MapWithContext(ctx, Ok[int, error](1), tt.fn); got.IsOk() != tt.wantOk {
+				t.Errorf("MapWithContext() = %v, want ok %v", got, tt.wantOk)
+			}
+		})
+	}
+
+	pointer := Map(Ok[int, *ptrError](1), func(x int) (int, *ptrError) { return x, nil })
+	zero := MapWithContext(ctx, Ok[int, codeError](1), func(x int) (int, codeError) { return x, 0 })
+	failing := Map(Ok[int, codeError](1), func(x int) (int, codeError) { return x, 4 })
+	if !pointer.IsOk() || zero.Unwrap() != 0 || zero.IsOk() || failing.Unwrap() != 4 {
+		t.Errorf("Map() with concrete error types = %v, %v, %v, want Ok, Err(code 0), Err(code 4)", pointer, zero, failing)
+	}
+}
@@ -0,0 +1,136 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CompensationError reports a compensation that failed while a Saga was rolling back.
+type CompensationError struct {
+	Step string // The name of the step whose compensation failed.
+	Err  error  // The error returned by the compensation.
+}
+
+// Error names the step and the compensation failure.
+func (e *CompensationError) Error() string {
+	return fmt.Sprintf("compensate %s: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the error returned by the compensation.
+func (e *CompensationError) Unwrap() error {
+	return e.Err
+}
+
+// SagaError is the error of a failed Saga: the step that failed, why, and every compensation that failed afterwards.
+type SagaError struct {
+	Step          string               // The name of the step that failed.
+	Cause         error                // The error of the failed step.
+	Compensations []*CompensationError // The compensations that failed, in the order they ran.
+}
+
+// Error describes the failed step followed by any compensation failures.
+func (e *SagaError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "saga step %s: %v", e.Step, e.Cause)
+	for _, c := range e.Compensations {
+		fmt.Fprintf(&b, "; %v", c)
+	}
+	return b.String()
+}
+
+// Unwrap returns the cause followed by the compensation failures, so errors.Is and errors.As can reach all of them.
+func (e *SagaError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Compensations)+1)
+	errs = append(errs, e.Cause)
+	for _, c := range e.Compensations {
+		errs = append(errs, c)
+	}
+	return errs
+}
+
+// errSagaNoCause stands in for the cause of a step that failed with a nil error.
+var errSagaNoCause = errors.New("step failed without an error")
+
+// Saga runs a sequence of steps and, on the first Failure, undoes the completed ones
+// by running their compensations in reverse order.
+// Steps are added with Step, and the zero value is an empty Saga ready to use.
+type Saga struct {
+	steps []sagaStep
+}
+
+// sagaStep is a step with its types erased.
+// run returns the compensation to register, or nil if the step has none.
+type sagaStep struct {
+	name string
+	run  func(context.Context) (compensate func(context.Context) error, err error)
+}
+
+// Step appends a step to s. action runs the step; if it succeeds and compensate is not nil,
+// compensate is registered to undo it with the value action produced.
+//
+// Example:
+//
+//	var order Saga
+//	Step(&order, "reserve", func(ctx context.Context) Result[Reservation, error] {
+//	    return stock.Reserve(ctx, items)
+//	}, func(ctx context.Context, r Reservation) error {
+//	    return stock.Release(ctx, r)
+//	})
+//	Step(&order, "charge", chargeCard, refundCard)
+//	Step(&order, "ship", ship, nil)
+//	result := order.Run(ctx)
+func Step[T any, E error](s *Saga, name string, action func(context.Context) Result[T, E], compensate func(context.Context, T) error) {
+	s.steps = append(s.steps, sagaStep{
+		name: name,
+		run: func(ctx context.Context) (func(context.Context) error, error) {
+			r := action(ctx).settle()
+			if r.state == Failure {
+				if err := error(r.fault); err != nil {
+					return nil, err
+				}
+				return nil, errSagaNoCause
+			}
+			if compensate == nil {
+				return nil, nil
+			}
+			value := r.value
+			return func(ctx context.Context) error { return compensate(ctx, value) }, nil
+		},
+	})
+}
+
+// Run runs the steps in order.
+// If a step fails, or ctx is done before a step starts, Run stops, runs the registered compensations
+// of the completed steps in reverse order and returns a Failure Result holding a *SagaError.
+// Compensations run with a context carrying ctx's values but not its cancellation, so a canceled Saga still rolls back.
+// Every compensation runs even if an earlier one failed.
+func (s *Saga) Run(ctx context.Context) Result[struct{}, *SagaError] {
+	type completed struct {
+		name       string
+		compensate func(context.Context) error
+	}
+	var done []completed
+	for _, step := range s.steps {
+		var compensate func(context.Context) error
+		err := ctx.Err()
+		if err == nil {
+			compensate, err = step.run(ctx)
+		}
+		if err != nil {
+			sagaErr := &SagaError{Step: step.name, Cause: err}
+			undoCtx := context.WithoutCancel(ctx)
+			for i := len(done) - 1; i >= 0; i-- {
+				if cerr := done[i].compensate(undoCtx); cerr != nil {
+					sagaErr.Compensations = append(sagaErr.Compensations, &CompensationError{Step: done[i].name, Err: cerr})
+				}
+			}
+			return Fail[struct{}](sagaErr)
+		}
+		if compensate != nil {
+			done = append(done, completed{name: step.name, compensate: compensate})
+		}
+	}
+	return Ok[struct{}, *SagaError](struct{}{})
+}
@@ -0,0 +1,109 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSaga(t *testing.T) {
+	errCharge := errors.New("card declined")
+	errRelease := errors.New("stock service down")
+	tests := []struct {
+		name       string
+		failCharge bool
+		failUndo   bool
+		wantLog    string
+		wantErrs   []error
+	}{
+		{name: "all steps succeed", wantLog: "reserve charge ship"},
+		{name: "compensates in reverse", failCharge: true, wantLog: "reserve audit charge! undo-audit undo-reserve", wantErrs: []error{errCharge}},
+		{name: "keeps compensation failures", failCharge: true, failUndo: true, wantLog: "reserve audit charge! undo-audit undo-reserve", wantErrs: []error{errCharge, errRelease}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			var s Saga
+			Step(&s, "reserve", func(context.Context) Result[string, error] {
+				log = append(log, "reserve")
+				return Ok[string, error]("r-1")
+			}, func(_ context.Context, id string) error {
+				log = append(log, "undo-reserve")
+				if id != "r-1" {
+					t.Errorf("compensation got %q, want the step's value", id)
+				}
+				if tt.failUndo {
+					return errRelease
+				}
+				return nil
+			})
+			if tt.failCharge {
+				Step(&s, "audit", func(context.Context) Result[int, error] {
+					log = append(log, "audit")
+					return Ok[int, error](1)
+				}, func(context.Context, int) error {
+					log = append(log, "undo-audit")
+					return nil
+				})
+			}
+			Step(&s, "charge", func(context.Context) Result[int, error] {
+				if tt.failCharge {
+					log = append(log, "charge!")
+					return Fail[int](errCharge)
+				}
+				log = append(log, "charge")
+				return Ok[int, error](100)
+			}, nil)
+			if !tt.failCharge {
+				Step[struct{}, error](&s, "ship", func(context.Context) Result[struct{}, error] {
+					log = append(log, "ship")
+					return Ok[struct{}, error](struct{}{})
+				}, nil)
+			}
+
+			r := s.Run(context.Background())
+			if got := strings.Join(log, " "); got != tt.wantLog {
+				t.Errorf("Saga.Run() ran %q, want %q", got, tt.wantLog)
+			}
+			if len(tt.wantErrs) == 0 {
+				if r.IsErr() {
+					t.Errorf("Saga.Run() = %v, want success", r)
+				}
+				return
+			}
+			sagaErr := r.Unwrap()
+			if sagaErr == nil || sagaErr.Step != "charge" {
+				t.Fatalf("Saga.Run() = %v, want a failure at charge", r)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(sagaErr, want) {
+					t.Errorf("Saga.Run() error = %v, want it to contain %v", sagaErr, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSagaCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	undone := false
+	var s Saga
+	Step(&s, "first", func(context.Context) Result[int, error] {
+		cancel()
+		return Ok[int, error](1)
+	}, func(ctx context.Context, _ int) error {
+		undone = ctx.Err() == nil
+		return nil
+	})
+	Step(&s, "second", func(context.Context) Result[int, error] {
+		t.Errorf("Saga.Run() should not start a step after cancellation")
+		return Ok[int, error](2)
+	}, nil)
+
+	r := s.Run(ctx)
+	if !errors.Is(r.Unwrap(), context.Canceled) || !undone {
+		t.Errorf("Saga.Run() canceled = %v, compensated with a live context %v", r, undone)
+	}
+}
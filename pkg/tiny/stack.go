@@ -0,0 +1,66 @@
+package tiny
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// maxStackDepth is the most frames a captured stack keeps.
+const maxStackDepth = 32
+
+// stackTraces records whether Fail and Wrap capture stacks.
+var stackTraces atomic.Bool
+
+// EnableStackTraces turns the capture of the caller's stack in Fail, and in Wrap for a Failure that has none yet, on or off
+// for the whole program. It is off by default; when off, the only cost is an atomic load per Failure.
+// FailWithStack captures a stack either way.
+func EnableStackTraces(on bool) {
+	stackTraces.Store(on)
+}
+
+// stack is the program counters of a captured stack, resolved into frames only when asked for.
+type stack struct {
+	pcs []uintptr
+}
+
+// captureStack returns the stack of the caller of the function that calls it,
+// leaving out skip more frames of tiny functions between the two.
+func captureStack(skip int) *stack {
+	pcs := make([]uintptr, maxStackDepth)
+	// Skip runtime.Callers, captureStack and the tiny function that called it.
+	n := runtime.Callers(3+skip, pcs)
+	return &stack{pcs: pcs[:n]}
+}
+
+// FailWithStack creates a Failure like Fail, recording the caller's stack even if stack traces are not enabled.
+//
+// Example:
+//
+//	if err != nil {
+//	    return FailWithStack[*User](err)
+//	}
+func FailWithStack[T any, E error](err E) Result[T, E] {
+	return Result[T, E]{state: Failure, fault: err, stack: captureStack(0)}
+}
+
+// StackTrace returns the stack captured when the Failure was created or first wrapped, or nil if none was captured.
+// Every combinator that passes a Failure on, such as Then, Map, MapErr or All, keeps its stack.
+func (r Result[T, E]) StackTrace() []runtime.Frame {
+	if r.stack == nil {
+		return nil
+	}
+	frames := runtime.CallersFrames(r.stack.pcs)
+	var out []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		out = append(out, frame)
+		if !more {
+			return out
+		}
+	}
+}
+
+// propagate returns a Failure of fault that keeps the stack of r, for combinators that pass a Failure on.
+func propagate[U any, F error, T any, E error](r Result[T, E], fault F) Result[U, F] {
+	return Result[U, F]{state: Failure, fault: fault, stack: r.stack}
+}
@@ -0,0 +1,113 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// enableStackTraces turns stack traces on for the rest of the test.
+func enableStackTraces(t *testing.T) {
+	EnableStackTraces(true)
+	t.Cleanup(func() { EnableStackTraces(false) })
+}
+
+var errBoomForAllocs = errors.New("boom")
+
+func failHere() Result[int, error] {
+	return Fail[int](errors.New("boom"))
+}
+
+func TestStackTraceDisabled(t *testing.T) {
+	if got := failHere().StackTrace(); got != nil {
+		t.Errorf("StackTrace() = %v, want nil while stack traces are off", got)
+	}
+	if got := failHere().Wrap("ctx").StackTrace(); got != nil {
+		t.Errorf("Wrap().StackTrace() = %v, want nil while stack traces are off", got)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Fail[int](errBoomForAllocs)
+	})
+	if allocs != 0 {
+		t.Errorf("Fail() allocates %v times while stack traces are off, want 0", allocs)
+	}
+}
+
+func TestFailWithStack(t *testing.T) {
+	frames := FailWithStack[int](errors.New("boom")).StackTrace()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestFailWithStack") {
+		t.Fatalf("StackTrace() = %v, want it to start in TestFailWithStack", frames)
+	}
+	if !strings.HasSuffix(frames[0].File, "stack_test.go") {
+		t.Errorf("StackTrace()[0].File = %s, want stack_test.go", frames[0].File)
+	}
+}
+
+func TestStackTracePropagates(t *testing.T) {
+	enableStackTraces(t)
+	origin := failHere()
+	frames := origin.StackTrace()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "failHere") {
+		t.Fatalf("StackTrace() = %v, want it to start in failHere", frames)
+	}
+
+	passed := map[string][]string{
+		"Then":   functions(origin.Then(func(int) Result[int, error] { return Ok[int, error](1) }).StackTrace()),
+		"Map":    functions(Map(origin, func(v int) (string, error) { return "", nil }).StackTrace()),
+		"MapErr": functions(MapErr(origin, func(err error) error { return fmt.Errorf("x: %w", err) }).StackTrace()),
+		"All":    functions(All(Ok[int, error](1), origin).StackTrace()),
+		"Wrap":   functions(origin.Wrap("ctx").StackTrace()),
+	}
+	want := functions(frames)
+	for name, got := range passed {
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s lost the stack: got %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestFromStackStartsAtCaller(t *testing.T) {
+	enableStackTraces(t)
+	boom := func(int) (int, error) { return 0, errors.New("boom") }
+	tests := map[string]Result[int, error]{
+		"From":           From(0, errors.New("boom")),
+		"Map":            Map(Ok[int, error](1), boom),
+		"MapWithContext": MapWithContext(context.Background(), Ok[int, error](1), boom),
+	}
+	for name, r := range tests {
+		if frames := r.StackTrace(); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestFromStackStartsAtCaller") {
+			t.Errorf("%s().StackTrace() = %v, want it to start at the caller", name, functions(frames))
+		}
+	}
+}
+
+func TestWrapCapturesStack(t *testing.T) {
+	unstacked := failHere()
+	enableStackTraces(t)
+	frames := unstacked.Wrap("ctx").StackTrace()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestWrapCapturesStack") {
+		t.Errorf("Wrap().StackTrace() = %v, want it to start where the Failure was first wrapped", frames)
+	}
+}
+
+func TestFormatStack(t *testing.T) {
+	got := fmt.Sprintf("%+v", FailWithStack[int](errors.New("boom")))
+	if !strings.HasPrefix(got, "Err(boom)\n    *errors.errorString: boom\n    failed at:\n        ") || !strings.Contains(got, "TestFormatStack") {
+		t.Errorf("%%+v = %q, want the chain followed by the captured stack", got)
+	}
+	if got := fmt.Sprintf("%v", FailWithStack[int](errors.New("boom"))); got != "Err(boom)" {
+		t.Errorf("%%v = %q, want no stack", got)
+	}
+}
+
+// functions returns the function names of frames.
+func functions(frames []runtime.Frame) []string {
+	var names []string
+	for _, f := range frames {
+		names = append(names, f.Function)
+	}
+	return names
+}
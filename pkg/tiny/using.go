@@ -0,0 +1,95 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Using acquires a resource, applies use to it and releases it.
+// If acquire fails, its error is returned and neither use nor release is called.
+// Otherwise release runs exactly once, after use returns or panics; a panic is re-raised once the resource is released.
+// A release error is joined with the error of use through errors.Join and converted into E as by AdaptError.
+// If it cannot be converted, as for a concrete E with no registered adapter, the Result of use is returned
+// and the release error is dropped; use E = error or register an adapter to observe it.
+//
+// Example:
+//
+//	r := Using(
+//	    func() Result[*os.File, error] { return open("data.txt") },
+//	    func(f *os.File) Result[int, error] { return count(f) },
+//	    (*os.File).Close,
+//	)
+func Using[R, T any, E error](acquire func() Result[R, E], use func(R) Result[T, E], release func(R) error) Result[T, E] {
+	acquired := acquire().settle()
+	if acquired.state == Failure {
+		return propagate[T](acquired, acquired.fault)
+	}
+	return useAndRelease(acquired.value, use, release)
+}
+
+// UsingWithContext is the context-aware form of Using.
+// If ctx is done before the resource is acquired, it returns a Failure Result with the context error and calls nothing.
+// If ctx is done right after acquisition, the resource is released without calling use.
+// use receives ctx and should return promptly once it is done; release always waits for use to return,
+// so a resource is never released while it is still in use.
+// Context errors are converted into E with AdaptError, and release errors as in Using.
+func UsingWithContext[R, T any, E error](ctx context.Context, acquire func(context.Context) Result[R, E], use func(context.Context, R) Result[T, E], release func(R) error) Result[T, E] {
+	if err := ctx.Err(); err != nil {
+		return Fail[T, E](AdaptError[E](err))
+	}
+	acquired := acquire(ctx).settle()
+	if acquired.state == Failure {
+		return propagate[T](acquired, acquired.fault)
+	}
+	return useAndRelease(acquired.value, func(resource R) Result[T, E] {
+		if err := ctx.Err(); err != nil {
+			return Fail[T, E](AdaptError[E](err))
+		}
+		return use(ctx, resource)
+	}, release)
+}
+
+// AsyncUsingWithContext runs UsingWithContext on its own goroutine.
+// It returns a channel that will receive the Result once the resource has been released.
+//
+// Example:
+//
+//	ch := AsyncUsingWithContext(ctx, acquireConn, func(ctx context.Context, c *Conn) Result[Reply, error] {
+//	    return c.Call(ctx, req)
+//	}, (*Conn).Close)
+//	result := <-ch
+func AsyncUsingWithContext[R, T any, E error](ctx context.Context, acquire func(context.Context) Result[R, E], use func(context.Context, R) Result[T, E], release func(R) error) <-chan Result[T, E] {
+	ch := make(chan Result[T, E], 1)
+	go func() {
+		defer close(ch)
+		ch <- UsingWithContext(ctx, acquire, use, release)
+	}()
+	return ch
+}
+
+// useAndRelease applies use to resource and releases it exactly once, even if use panics.
+func useAndRelease[R, T any, E error](resource R, use func(R) Result[T, E], release func(R) error) Result[T, E] {
+	released := false
+	defer func() {
+		if !released {
+			// use panicked: release before the panic continues unwinding.
+			_ = release(resource)
+		}
+	}()
+
+	result := use(resource).settle()
+	released = true
+	err := release(resource)
+	if err == nil {
+		return result
+	}
+	err = fmt.Errorf("release: %w", err)
+	if result.state == Failure {
+		err = errors.Join(result.fault, err)
+	}
+	if fault, ok := tryAdaptError[E](err); ok {
+		return Fail[T, E](fault)
+	}
+	return result
+}
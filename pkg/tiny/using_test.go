@@ -0,0 +1,177 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testResource struct {
+	releases int
+}
+
+func TestUsing(t *testing.T) {
+	errUse := errors.New("use failed")
+	errRelease := errors.New("release failed")
+	tests := []struct {
+		name       string
+		acquireErr error
+		useErr     error
+		releaseErr error
+		want       int
+		wantErrs   []error
+		wantCalls  int
+	}{
+		{name: "success", want: 42, wantCalls: 1},
+		{name: "acquire fails", acquireErr: errUse, wantErrs: []error{errUse}, wantCalls: 0},
+		{name: "use fails", useErr: errUse, wantErrs: []error{errUse}, wantCalls: 1},
+		{name: "release fails", releaseErr: errRelease, wantErrs: []error{errRelease}, wantCalls: 1},
+		{name: "both fail", useErr: errUse, releaseErr: errRelease, wantErrs: []error{errUse, errRelease}, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &testResource{}
+			got := Using(
+				func() Result[*testResource, error] {
+					if tt.acquireErr != nil {
+						return Fail[*testResource](tt.acquireErr)
+					}
+					return Ok[*testResource, error](res)
+				},
+				func(*testResource) Result[int, error] {
+					if tt.useErr != nil {
+						return Fail[int](tt.useErr)
+					}
+					return Ok[int, error](42)
+				},
+				func(r *testResource) error {
+					r.releases++
+					return tt.releaseErr
+				},
+			)
+			if res.releases != tt.wantCalls {
+				t.Errorf("Using() released %d times, want %d", res.releases, tt.wantCalls)
+			}
+			if len(tt.wantErrs) == 0 && got.OrElse(0) != tt.want {
+				t.Errorf("Using() = %v, want %v", got, tt.want)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(got.Unwrap(), want) {
+					t.Errorf("Using() error = %v, want it to contain %v", got.Unwrap(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestUsingPanic(t *testing.T) {
+	res := &testResource{}
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Using() should re-raise the panic, got %v", r)
+		}
+		if res.releases != 1 {
+			t.Errorf("Using() should release once on panic, got %d", res.releases)
+		}
+	}()
+	Using(
+		func() Result[*testResource, error] { return Ok[*testResource, error](res) },
+		func(*testResource) Result[int, error] { panic("boom") },
+		func(r *testResource) error { r.releases++; return nil },
+	)
+}
+
+func TestUsingWithContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	acquired := false
+	got := UsingWithContext(canceled,
+		func(context.Context) Result[*testResource, error] {
+			acquired = true
+			return Ok[*testResource, error](&testResource{})
+		},
+		func(context.Context, *testResource) Result[int, error] { return Ok[int, error](1) },
+		func(*testResource) error { return nil },
+	)
+	if acquired || !errors.Is(got.Unwrap(), context.Canceled) {
+		t.Errorf("UsingWithContext() on a canceled context should not acquire, got %v", got)
+	}
+
+	ctx, cancelDuring := context.WithCancel(context.Background())
+	defer cancelDuring()
+	res := &testResource{}
+	used := false
+	got = UsingWithContext(ctx,
+		func(context.Context) Result[*testResource, error] {
+			cancelDuring()
+			return Ok[*testResource, error](res)
+		},
+		func(context.Context, *testResource) Result[int, error] {
+			used = true
+			return Ok[int, error](1)
+		},
+		func(r *testResource) error { r.releases++; return nil },
+	)
+	if used || res.releases != 1 || !errors.Is(got.Unwrap(), context.Canceled) {
+		t.Errorf("UsingWithContext() canceled after acquire should release without use, got %v, used %v, releases %d", got, used, res.releases)
+	}
+}
+
+func TestAsyncUsingWithContext(t *testing.T) {
+	res := &testResource{}
+	ch := AsyncUsingWithContext(context.Background(),
+		func(context.Context) Result[*testResource, error] { return Ok[*testResource, error](res) },
+		func(_ context.Context, r *testResource) Result[int, error] { return Ok[int, error](7) },
+		func(r *testResource) error { r.releases++; return nil },
+	)
+	got := <-ch
+	if got.OrElse(0) != 7 || res.releases != 1 {
+		t.Errorf("AsyncUsingWithContext() = %v, releases %d", got, res.releases)
+	}
+}
+
+type usingTestError struct{ msg string }
+
+func (e *usingTestError) Error() string { return e.msg }
+
+func TestUsingConcreteError(t *testing.T) {
+	errUse := &usingTestError{msg: "use failed"}
+	tests := []struct {
+		name    string
+		useErr  *usingTestError
+		wantErr *usingTestError
+	}{
+		{name: "release fails after success", wantErr: nil},
+		{name: "both fail", useErr: errUse, wantErr: errUse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &testResource{}
+			got := Using(
+				func() Result[*testResource, *usingTestError] { return Ok[*testResource, *usingTestError](res) },
+				func(*testResource) Result[int, *usingTestError] {
+					if tt.useErr != nil {
+						return Fail[int](tt.useErr)
+					}
+					return Ok[int, *usingTestError](42)
+				},
+				func(r *testResource) error {
+					r.releases++
+					return errors.New("release failed")
+				},
+			)
+			if res.releases != 1 {
+				t.Errorf("Using() released %d times, want 1", res.releases)
+			}
+			if tt.wantErr == nil && got.OrElse(0) != 42 {
+				t.Errorf("Using() = %v, want the Result of use", got)
+			}
+			if tt.wantErr != nil && got.Unwrap() != tt.wantErr {
+				t.Errorf("Using() error = %v, want %v", got.Unwrap(), tt.wantErr)
+			}
+		})
+	}
+}
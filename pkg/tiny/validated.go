@@ -0,0 +1,227 @@
+package tiny
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// FieldError describes a single failed check, located by a dotted field path such as "address.city" or "items[2].name".
+type FieldError struct {
+	Path string // The path of the field that failed, empty for the value itself.
+	Err  error  // The reason the check failed.
+}
+
+// Error returns the failure prefixed with its field path.
+func (f FieldError) Error() string {
+	if f.Path == "" {
+		return f.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", f.Path, f.Err)
+}
+
+// Unwrap returns the underlying reason so errors.Is and errors.As can see through a FieldError.
+func (f FieldError) Unwrap() error {
+	return f.Err
+}
+
+// ValidationErrors collects every FieldError produced while validating a value.
+// It is the error type of a Result converted from a Validated value.
+type ValidationErrors struct {
+	Fields []FieldError
+}
+
+// Error returns all field failures joined by "; ".
+func (v *ValidationErrors) Error() string {
+	msgs := make([]string, len(v.Fields))
+	for i, f := range v.Fields {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the individual field failures so errors.Is and errors.As can inspect each one.
+func (v *ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(v.Fields))
+	for i, f := range v.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Validation is implemented by every Validated value, whatever its type parameter.
+// It lets checks on fields of different types be combined with Validate.
+type Validation interface {
+	// Errors returns the failures accumulated so far, or nil if the value is valid.
+	Errors() []FieldError
+}
+
+// Validated holds a value together with every validation failure found for it.
+// Unlike Result.Then, combining Validated values never short-circuits: all failures are kept.
+type Validated[T any] struct {
+	value T            // The value being validated.
+	errs  []FieldError // The accumulated failures, empty if the value is valid.
+}
+
+// Valid creates a Validated value with no failures.
+func Valid[T any](value T) Validated[T] {
+	return Validated[T]{value: value}
+}
+
+// Invalid creates a Validated value holding the given failures.
+func Invalid[T any](errs ...FieldError) Validated[T] {
+	return Validated[T]{errs: errs}
+}
+
+// IsValid reports whether no failures have been recorded.
+func (v Validated[T]) IsValid() bool {
+	return len(v.errs) == 0
+}
+
+// Errors returns the accumulated failures, or nil if the value is valid.
+func (v Validated[T]) Errors() []FieldError {
+	return v.errs
+}
+
+// Result converts the Validated value into a Result.
+// If there are no failures, it returns a Success Result with the value.
+// Otherwise, it returns a Failure Result holding every failure in a *ValidationErrors.
+func (v Validated[T]) Result() Result[T, *ValidationErrors] {
+	if len(v.errs) == 0 {
+		return Ok[T, *ValidationErrors](v.value)
+	}
+	return Fail[T, *ValidationErrors](&ValidationErrors{Fields: v.errs})
+}
+
+// Rule checks a single value, returning nil if it passes or an error describing why it does not.
+type Rule[T any] func(T) error
+
+// Field validates value against every rule and records each failure under path.
+// All rules are evaluated; a failing rule does not prevent the following ones from running.
+//
+// Example:
+//
+//	name := Field("name", u.Name, Required[string](), MinLen(3))
+func Field[T any](path string, value T, rules ...Rule[T]) Validated[T] {
+	v := Valid(value)
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			v.errs = append(v.errs, FieldError{Path: path, Err: err})
+		}
+	}
+	return v
+}
+
+// Validate combines independent checks on the parts of value, accumulating every failure.
+// It returns a valid Validated value only if all checks are valid.
+//
+// Example:
+//
+//	v := Validate(u,
+//	    Field("name", u.Name, Required[string](), MinLen(3)),
+//	    Field("age", u.Age, Range(0, 150)),
+//	    Nest("address", validateAddress(u.Address)),
+//	)
+//	result := v.Result() // Result[User, *ValidationErrors]
+func Validate[T any](value T, checks ...Validation) Validated[T] {
+	v := Valid(value)
+	for _, c := range checks {
+		v.errs = append(v.errs, c.Errors()...)
+	}
+	return v
+}
+
+// Nest prefixes the path of every failure in v with prefix, so that a validator written for a nested type
+// reports paths such as "address.city".
+func Nest[T any](prefix string, v Validated[T]) Validated[T] {
+	if len(v.errs) == 0 {
+		return v
+	}
+	errs := make([]FieldError, len(v.errs))
+	for i, f := range v.errs {
+		errs[i] = FieldError{Path: joinPath(prefix, f.Path), Err: f.Err}
+	}
+	return Validated[T]{value: v.value, errs: errs}
+}
+
+// Each validates every element of items with fn, reporting failures under indexed paths such as "items[2].name".
+func Each[T any](path string, items []T, fn func(T) Validated[T]) Validated[[]T] {
+	v := Valid(items)
+	for i, item := range items {
+		nested := Nest(fmt.Sprintf("%s[%d]", path, i), fn(item))
+		v.errs = append(v.errs, nested.errs...)
+	}
+	return v
+}
+
+// Map2 combines two Validated values with fn.
+// If either value is invalid, it returns the failures of both; fn is only called when both are valid.
+func Map2[A, B, C any](va Validated[A], vb Validated[B], fn func(A, B) C) Validated[C] {
+	if len(va.errs) > 0 || len(vb.errs) > 0 {
+		errs := make([]FieldError, 0, len(va.errs)+len(vb.errs))
+		errs = append(errs, va.errs...)
+		errs = append(errs, vb.errs...)
+		return Invalid[C](errs...)
+	}
+	return Valid(fn(va.value, vb.value))
+}
+
+// joinPath joins a parent and child field path, omitting the dot before an index.
+func joinPath(prefix, path string) string {
+	switch {
+	case path == "":
+		return prefix
+	case prefix == "":
+		return path
+	case strings.HasPrefix(path, "["):
+		return prefix + path
+	default:
+		return prefix + "." + path
+	}
+}
+
+// ErrRequired is returned by the Required rule.
+var ErrRequired = errors.New("is required")
+
+// Required fails if the value is the zero value of its type.
+func Required[T any]() Rule[T] {
+	return func(v T) error {
+		if reflect.ValueOf(&v).Elem().IsZero() {
+			return ErrRequired
+		}
+		return nil
+	}
+}
+
+// MinLen fails if the string is shorter than n characters.
+func MinLen(n int) Rule[string] {
+	return func(s string) error {
+		if l := len([]rune(s)); l < n {
+			return fmt.Errorf("length %d is less than %d", l, n)
+		}
+		return nil
+	}
+}
+
+// Range fails if the value lies outside the inclusive range [lo, hi].
+func Range[T cmp.Ordered](lo, hi T) Rule[T] {
+	return func(v T) error {
+		if v < lo || v > hi {
+			return fmt.Errorf("%v is not between %v and %v", v, lo, hi)
+		}
+		return nil
+	}
+}
+
+// Matches fails if the string does not match re.
+func Matches(re *regexp.Regexp) Rule[string] {
+	return func(s string) error {
+		if !re.MatchString(s) {
+			return fmt.Errorf("%q does not match %s", s, re)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+type testAddress struct {
+	City string
+	Zip  string
+}
+
+type testUser struct {
+	Name    string
+	Age     int
+	Address testAddress
+	Tags    []string
+}
+
+var zipPattern = regexp.MustCompile(`^\d{5}$`)
+
+func validateTestAddress(a testAddress) Validated[testAddress] {
+	return Validate(a,
+		Field("city", a.City, Required[string]()),
+		Field("zip", a.Zip, Matches(zipPattern)),
+	)
+}
+
+func validateTestUser(u testUser) Validated[testUser] {
+	return Validate(u,
+		Field("name", u.Name, Required[string](), MinLen(3)),
+		Field("age", u.Age, Range(0, 150)),
+		Nest("address", validateTestAddress(u.Address)),
+		Each("tags", u.Tags, func(tag string) Validated[string] {
+			return Field("", tag, MinLen(2))
+		}),
+	)
+}
+
+func ExampleValidate() {
+	u := testUser{Name: "Al", Age: 200, Address: testAddress{City: "Paris", Zip: "75001"}}
+	fmt.Println(validateTestUser(u).Result())
+	// Output: Err(name: length 2 is less than 3; age: 200 is not between 0 and 150)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     testUser
+		wantPaths []string
+	}{
+		{
+			name:      "valid",
+			input:     testUser{Name: "Alice", Age: 30, Address: testAddress{City: "Paris", Zip: "75001"}, Tags: []string{"go"}},
+			wantPaths: nil,
+		},
+		{
+			name:      "accumulates every failure",
+			input:     testUser{Name: "", Age: -1, Address: testAddress{Zip: "abc"}, Tags: []string{"go", "x"}},
+			wantPaths: []string{"name", "name", "age", "address.city", "address.zip", "tags[1]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateTestUser(tt.input)
+			if got.IsValid() != (len(tt.wantPaths) == 0) {
+				t.Fatalf("IsValid() = %v, errors %v", got.IsValid(), got.Errors())
+			}
+			errs := got.Errors()
+			if len(errs) != len(tt.wantPaths) {
+				t.Fatalf("Errors() = %v, want paths %v", errs, tt.wantPaths)
+			}
+			for i, path := range tt.wantPaths {
+				if errs[i].Path != path {
+					t.Errorf("Errors()[%d].Path = %q, want %q", i, errs[i].Path, path)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatedResult(t *testing.T) {
+	ok := Valid(5).Result()
+	if ok.UnwrapOrPanic() != 5 {
+		t.Errorf("Result on valid value should succeed, got %v", ok)
+	}
+
+	bad := Field("name", "", Required[string]()).Result()
+	if bad.state != Failure {
+		t.Fatalf("Result on invalid value should fail, got %v", bad)
+	}
+	if !errors.Is(bad.Unwrap(), ErrRequired) {
+		t.Errorf("ValidationErrors should unwrap to ErrRequired, got %v", bad.Unwrap())
+	}
+	var fe FieldError
+	if !errors.As(bad.Unwrap(), &fe) || fe.Path != "name" {
+		t.Errorf("ValidationErrors should expose the FieldError for name, got %v", fe)
+	}
+}
+
+func TestMap2(t *testing.T) {
+	sum := Map2(Valid(1), Valid(2), func(a, b int) int { return a + b })
+	if !sum.IsValid() || sum.value != 3 {
+		t.Errorf("Map2 of valid values should combine them, got %v", sum)
+	}
+
+	both := Map2(Field("a", 0, Required[int]()), Field("b", "", Required[string]()), func(int, string) int { return 0 })
+	if len(both.Errors()) != 2 {
+		t.Errorf("Map2 should keep failures from both sides, got %v", both.Errors())
+	}
+}
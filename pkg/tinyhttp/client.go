@@ -0,0 +1,137 @@
+package tinyhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// maxErrorBody bounds how much of a non-2xx response body is kept in an HTTPError.
+const maxErrorBody = 64 << 10
+
+// ErrorKind classifies an HTTPError.
+type ErrorKind int
+
+const (
+	// KindNetwork indicates that no response was received.
+	KindNetwork ErrorKind = iota
+	// KindStatus indicates that the server answered with a non-2xx status.
+	KindStatus
+	// KindDecode indicates that the response body could not be decoded.
+	KindDecode
+	// KindCanceled indicates that the context was canceled or its deadline passed.
+	KindCanceled
+)
+
+// Sentinel errors matching each ErrorKind with errors.Is.
+var (
+	ErrNetwork  = errors.New("network failure")
+	ErrStatus   = errors.New("unexpected status")
+	ErrDecode   = errors.New("decode failure")
+	ErrCanceled = errors.New("request canceled")
+)
+
+// HTTPError is the error type of Results returned by Do.
+type HTTPError struct {
+	Kind       ErrorKind // What went wrong.
+	StatusCode int       // The response status, set for KindStatus and KindDecode.
+	Body       []byte    // The start of the response body, set for KindStatus.
+	Err        error     // The underlying error, if any.
+}
+
+// Error describes the failure, including the status code for KindStatus.
+func (e *HTTPError) Error() string {
+	if e.Kind == KindStatus {
+		return fmt.Sprintf("%v: %d %s", e.sentinel(), e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%v: %v", e.sentinel(), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel error for e's Kind.
+func (e *HTTPError) Is(target error) bool {
+	return target == e.sentinel()
+}
+
+func (e *HTTPError) sentinel() error {
+	switch e.Kind {
+	case KindStatus:
+		return ErrStatus
+	case KindDecode:
+		return ErrDecode
+	case KindCanceled:
+		return ErrCanceled
+	default:
+		return ErrNetwork
+	}
+}
+
+func init() {
+	// Lets the context-aware tiny combinators deliver their own errors as *HTTPError.
+	tiny.RegisterErrorAdapter(transportError)
+}
+
+// transportError classifies an error that prevented a response from being received.
+func transportError(err error) *HTTPError {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &HTTPError{Kind: KindCanceled, Err: err}
+	}
+	return &HTTPError{Kind: KindNetwork, Err: err}
+}
+
+// Do sends req with client, bound to ctx, and decodes a 2xx JSON response body into T.
+// A nil client means http.DefaultClient. A 204 No Content response yields the zero value of T.
+// Failures are reported as *HTTPError: KindNetwork or KindCanceled if no response arrived,
+// KindStatus for a non-2xx status and KindDecode if the body is not valid JSON for T.
+//
+// Example:
+//
+//	req, _ := http.NewRequest(http.MethodGet, url, nil)
+//	user := Do[User](ctx, nil, req)
+func Do[T any](ctx context.Context, client *http.Client, req *http.Request) tiny.Result[T, *HTTPError] {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return tiny.Fail[T](transportError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return tiny.Fail[T](&HTTPError{Kind: KindStatus, StatusCode: resp.StatusCode, Body: body})
+	}
+	var value T
+	if resp.StatusCode == http.StatusNoContent {
+		return tiny.Ok[T, *HTTPError](value)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&value); err != nil {
+		return tiny.Fail[T](&HTTPError{Kind: KindDecode, StatusCode: resp.StatusCode, Err: err})
+	}
+	return tiny.Ok[T, *HTTPError](value)
+}
+
+// DoAsync runs Do on its own goroutine through tiny.AsyncThenWithContext.
+// The channel receives a KindCanceled failure as soon as ctx is done, even if the request is still in flight.
+//
+// Example:
+//
+//	users := DoAsync[User](ctx, nil, usersReq)
+//	orders := DoAsync[[]Order](ctx, nil, ordersReq)
+//	u, o := <-users, <-orders
+func DoAsync[T any](ctx context.Context, client *http.Client, req *http.Request) <-chan tiny.Result[T, *HTTPError] {
+	var seed T
+	return tiny.AsyncThenWithContext(ctx, tiny.Ok[T, *HTTPError](seed), func(T) tiny.Result[T, *HTTPError] {
+		return Do[T](ctx, client, req)
+	})
+}
@@ -0,0 +1,104 @@
+package tinyhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1","name":"Ada"}`))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such user", http.StatusNotFound)
+	})
+	mux.HandleFunc("/garbage", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDo(t *testing.T) {
+	srv := newTestServer(t)
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		want     user
+		wantErr  error
+		wantCode int
+	}{
+		{name: "success", url: srv.URL + "/user", want: user{ID: "1", Name: "Ada"}},
+		{name: "no content", url: srv.URL + "/empty"},
+		{name: "status", url: srv.URL + "/missing", wantErr: ErrStatus, wantCode: http.StatusNotFound},
+		{name: "decode", url: srv.URL + "/garbage", wantErr: ErrDecode, wantCode: http.StatusOK},
+		{name: "network", url: closed.URL, wantErr: ErrNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			got := Do[user](context.Background(), srv.Client(), req)
+			if tt.wantErr == nil {
+				if got.IsErr() || got.OrElse(user{ID: "unset"}) != tt.want {
+					t.Errorf("Do() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			err := got.Unwrap()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if err.StatusCode != tt.wantCode {
+				t.Errorf("Do() status = %d, want %d", err.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestDoStatusBody(t *testing.T) {
+	srv := newTestServer(t)
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/missing", nil)
+	err := Do[user](context.Background(), nil, req).Unwrap()
+	if string(err.Body) != "no such user\n" {
+		t.Errorf("Do() should keep the error body, got %q", err.Body)
+	}
+}
+
+func TestDoAsync(t *testing.T) {
+	srv := newTestServer(t)
+	req1, _ := http.NewRequest(http.MethodGet, srv.URL+"/user", nil)
+	req2, _ := http.NewRequest(http.MethodGet, srv.URL+"/missing", nil)
+
+	ch1 := DoAsync[user](context.Background(), nil, req1)
+	ch2 := DoAsync[user](context.Background(), nil, req2)
+	if r := <-ch1; r.IsErr() {
+		t.Errorf("DoAsync() = %v, want success", r)
+	}
+	if r := <-ch2; !errors.Is(r.Unwrap(), ErrStatus) {
+		t.Errorf("DoAsync() = %v, want status error", r)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	slow, _ := http.NewRequest(http.MethodGet, srv.URL+"/slow", nil)
+	r := <-DoAsync[user](ctx, nil, slow)
+	if !errors.Is(r.Unwrap(), ErrCanceled) || !errors.Is(r.Unwrap(), context.DeadlineExceeded) {
+		t.Errorf("DoAsync() = %v, want canceled", r)
+	}
+}
@@ -0,0 +1,210 @@
+// Package tinyhttp adapts tiny.Result to net/http, on both the server and the client side.
+package tinyhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// StatusClientClosedRequest is the non-standard status recorded when the client goes away before a response is ready.
+const StatusClientClosedRequest = 499
+
+// Problem is an RFC 7807 problem details object, written as application/problem+json for every Failure.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// Problemer is implemented by errors that want to describe their own problem details.
+// The Status field of the returned Problem is always overwritten with the mapped status code.
+type Problemer interface {
+	Problem() Problem
+}
+
+// StatusCoder is implemented by errors that carry their own HTTP status code.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// StatusMapper maps the error of a Failure to an HTTP status code.
+type StatusMapper func(err error) int
+
+// StatusRule maps an error to a status code, reporting false if the rule does not apply.
+type StatusRule func(err error) (int, bool)
+
+// StatusFor returns a StatusRule that maps any error in the chain assignable to X, found with errors.As, to status.
+//
+// Example:
+//
+//	mapper := NewStatusMapper(
+//	    StatusFor[*NotFoundError](http.StatusNotFound),
+//	    StatusFor[*ConflictError](http.StatusConflict),
+//	)
+func StatusFor[X error](status int) StatusRule {
+	return func(err error) (int, bool) {
+		var target X
+		if errors.As(err, &target) {
+			return status, true
+		}
+		return 0, false
+	}
+}
+
+// StatusIs returns a StatusRule that maps errors matching target with errors.Is to status.
+func StatusIs(target error, status int) StatusRule {
+	return func(err error) (int, bool) {
+		if errors.Is(err, target) {
+			return status, true
+		}
+		return 0, false
+	}
+}
+
+// NewStatusMapper builds a StatusMapper that tries rules in order and falls back to DefaultStatusMapper.
+func NewStatusMapper(rules ...StatusRule) StatusMapper {
+	return func(err error) int {
+		for _, rule := range rules {
+			if status, ok := rule(err); ok {
+				return status
+			}
+		}
+		return DefaultStatusMapper(err)
+	}
+}
+
+// DefaultStatusMapper maps errors implementing StatusCoder to their own code, validation errors to 422,
+// context deadlines to 504, context cancellation to 499 and everything else to 500.
+func DefaultStatusMapper(err error) int {
+	var coder StatusCoder
+	var invalid *tiny.ValidationErrors
+	switch {
+	case errors.As(err, &coder):
+		return coder.StatusCode()
+	case errors.As(err, &invalid):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Option configures a Handler.
+type Option func(*config)
+
+type config struct {
+	mapper        StatusMapper
+	successStatus int
+}
+
+// WithStatusMapper sets the StatusMapper used for Failures. The default is DefaultStatusMapper.
+func WithStatusMapper(m StatusMapper) Option {
+	return func(c *config) {
+		c.mapper = m
+	}
+}
+
+// WithSuccessStatus sets the status code written for a Success. The default is 200.
+func WithSuccessStatus(status int) Option {
+	return func(c *config) {
+		c.successStatus = status
+	}
+}
+
+// Handler adapts a Result-returning function to an http.Handler.
+// A Success is encoded as JSON with the success status.
+// A Failure is mapped to a status code by the StatusMapper and written as an RFC 7807 problem.
+// If the request context is done before fn returns, Handler writes and flushes a problem for the context error at once,
+// then waits for fn to return, as net/http forbids using the request once ServeHTTP has returned.
+// fn should therefore return promptly once r.Context() is done.
+// A panic in fn is re-raised on the serving goroutine, so net/http's own recovery still applies.
+//
+// Example:
+//
+//	http.Handle("/users/", Handler(func(r *http.Request) tiny.Result[User, error] {
+//	    return repo.Find(r.Context(), r.PathValue("id"))
+//	}))
+func Handler[T any, E error](fn func(*http.Request) tiny.Result[T, E], opts ...Option) http.Handler {
+	cfg := config{mapper: DefaultStatusMapper, successStatus: http.StatusOK}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type outcome struct {
+			result tiny.Result[T, E]
+			panic  any
+		}
+		done := make(chan outcome, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					done <- outcome{panic: p}
+				}
+			}()
+			done <- outcome{result: fn(r)}
+		}()
+
+		select {
+		case out := <-done:
+			if out.panic != nil {
+				panic(out.panic)
+			}
+			if out.result.IsErr() {
+				writeProblem(w, r, cfg.mapper, out.result.Unwrap())
+				return
+			}
+			var zero T
+			writeJSON(w, r, cfg, out.result.OrElse(zero))
+		case <-r.Context().Done():
+			writeProblem(w, r, cfg.mapper, r.Context().Err())
+			_ = http.NewResponseController(w).Flush()
+			if out := <-done; out.panic != nil {
+				panic(out.panic)
+			}
+		}
+	})
+}
+
+// writeJSON encodes v before writing any header, so that an encoding failure can still be reported as a problem.
+func writeJSON(w http.ResponseWriter, r *http.Request, cfg config, v any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		writeProblem(w, r, cfg.mapper, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(cfg.successStatus)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// writeProblem writes err as an application/problem+json response.
+func writeProblem(w http.ResponseWriter, r *http.Request, mapper StatusMapper, err error) {
+	status := mapper(err)
+	p := Problem{Type: "about:blank", Title: statusText(status), Detail: err.Error(), Instance: r.URL.Path}
+	var custom Problemer
+	if errors.As(err, &custom) {
+		p = custom.Problem()
+	}
+	p.Status = status
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// statusText extends http.StatusText with StatusClientClosedRequest.
+func statusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(status)
+}
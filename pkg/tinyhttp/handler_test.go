@@ -0,0 +1,187 @@
+package tinyhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+type notFoundError struct{ id string }
+
+func (e *notFoundError) Error() string { return "no user " + e.id }
+
+type teapotError struct{}
+
+func (teapotError) Error() string   { return "short and stout" }
+func (teapotError) StatusCode() int { return http.StatusTeapot }
+
+type user struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestHandler(t *testing.T) {
+	mapper := NewStatusMapper(StatusFor[*notFoundError](http.StatusNotFound))
+	tests := []struct {
+		name       string
+		fn         func(*http.Request) tiny.Result[user, error]
+		wantStatus int
+		wantType   string
+	}{
+		{
+			name: "success",
+			fn: func(*http.Request) tiny.Result[user, error] {
+				return tiny.Ok[user, error](user{ID: "1", Name: "Ada"})
+			},
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+		},
+		{
+			name: "mapped error",
+			fn: func(*http.Request) tiny.Result[user, error] {
+				return tiny.Fail[user, error](&notFoundError{id: "2"})
+			},
+			wantStatus: http.StatusNotFound,
+			wantType:   "application/problem+json",
+		},
+		{
+			name: "wrapped mapped error",
+			fn: func(*http.Request) tiny.Result[user, error] {
+				return tiny.Fail[user](error(&notFoundError{id: "2"})).Wrap("lookup")
+			},
+			wantStatus: http.StatusNotFound,
+			wantType:   "application/problem+json",
+		},
+		{
+			name: "status coder",
+			fn: func(*http.Request) tiny.Result[user, error] {
+				return tiny.Fail[user, error](teapotError{})
+			},
+			wantStatus: http.StatusTeapot,
+			wantType:   "application/problem+json",
+		},
+		{
+			name: "validation error",
+			fn: func(*http.Request) tiny.Result[user, error] {
+				return tiny.MapErr(tiny.Field("name", user{}, tiny.Required[user]()).Result(), func(e *tiny.ValidationErrors) error { return e })
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantType:   "application/problem+json",
+		},
+		{
+			name: "unknown error",
+			fn: func(*http.Request) tiny.Result[user, error] {
+				return tiny.Fail[user, error](errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "application/problem+json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			Handler(tt.fn, WithStatusMapper(mapper)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Handler() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Handler() Content-Type = %q, want %q", got, tt.wantType)
+			}
+			if tt.wantType != "application/problem+json" {
+				return
+			}
+			var p Problem
+			if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+				t.Fatalf("Handler() problem body: %v", err)
+			}
+			if p.Status != tt.wantStatus || p.Title == "" || p.Detail == "" || p.Instance != "/users/1" {
+				t.Errorf("Handler() problem = %+v", p)
+			}
+		})
+	}
+}
+
+func TestHandlerSuccessBody(t *testing.T) {
+	h := Handler(func(*http.Request) tiny.Result[user, error] {
+		return tiny.Ok[user, error](user{ID: "1", Name: "Ada"})
+	}, WithSuccessStatus(http.StatusCreated))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Handler() status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	var got user
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil || got.Name != "Ada" {
+		t.Errorf("Handler() body = %+v, err %v", got, err)
+	}
+}
+
+func TestHandlerContextCanceled(t *testing.T) {
+	h := Handler(func(r *http.Request) tiny.Result[user, error] {
+		<-r.Context().Done()
+		return tiny.Ok[user, error](user{})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil).WithContext(ctx))
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("Handler() status = %d, want %d", rec.Code, http.StatusGatewayTimeout)
+	}
+}
+
+// flushRecorder signals flushed once the handler has flushed its response.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed chan struct{}
+}
+
+func (w *flushRecorder) Flush() {
+	w.ResponseRecorder.Flush()
+	close(w.flushed)
+}
+
+func TestHandlerWaitsForAbandonedFn(t *testing.T) {
+	w := &flushRecorder{ResponseRecorder: httptest.NewRecorder(), flushed: make(chan struct{})}
+	var fnReturned atomic.Bool
+	h := Handler(func(r *http.Request) tiny.Result[user, error] {
+		<-r.Context().Done()
+		<-w.flushed // The problem reaches the client while fn is still running.
+		fnReturned.Store(true)
+		return tiny.Ok[user, error](user{})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil).WithContext(ctx))
+
+	if !fnReturned.Load() {
+		t.Error("Handler() returned while fn was still using the request")
+	}
+	if w.Code != StatusClientClosedRequest {
+		t.Errorf("Handler() status = %d, want %d", w.Code, StatusClientClosedRequest)
+	}
+}
+
+func TestHandlerPanic(t *testing.T) {
+	h := Handler(func(*http.Request) tiny.Result[user, error] {
+		panic("boom")
+	})
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Handler() should re-raise the panic on the serving goroutine, got %v", r)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
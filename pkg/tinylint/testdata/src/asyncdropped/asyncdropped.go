@@ -0,0 +1,44 @@
+package asyncdropped
+
+import (
+	"context"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+func double(v int) tiny.Result[int, error] { return tiny.Ok[int, error](v * 2) }
+
+func async(ctx context.Context) tiny.Result[int, error] {
+	tiny.AsyncThen(double(1), double)                     // want "channel returned by AsyncThen is never received from"
+	_ = tiny.AsyncThenWithContext(ctx, double(1), double) // want "channel returned by AsyncThenWithContext is never received from"
+	var _ = tiny.AsyncThen(double(1), double)             // want "channel returned by AsyncThen is never received from"
+	ch := tiny.AsyncThen(double(1), double)
+	return <-ch
+}
+
+func stored(ctx context.Context) {
+	discarded := tiny.AsyncThen(double(1), double) // want "channel returned by AsyncThen is never received from"
+	_ = discarded
+	var unread = tiny.AsyncThenWithContext(ctx, double(1), double) // want "channel returned by AsyncThenWithContext is never received from"
+	var _ = unread
+	overwritten := tiny.AsyncThen(double(1), double) // want "channel returned by AsyncThen is never received from"
+	overwritten = nil
+	_ = overwritten
+}
+
+func received(ctx context.Context, out chan<- (<-chan tiny.Result[int, error])) int {
+	ranged := tiny.AsyncThen(double(1), double)
+	for r := range ranged {
+		_ = r
+	}
+	selected := tiny.AsyncThenWithContext(ctx, double(1), double)
+	select {
+	case <-selected:
+	case <-ctx.Done():
+	}
+	passed := tiny.AsyncThen(double(1), double)
+	out <- passed
+	later := tiny.AsyncThen(double(1), double)
+	go func() { <-later }()
+	return 0
+}
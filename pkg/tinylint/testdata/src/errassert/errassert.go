@@ -0,0 +1,17 @@
+package errassert
+
+type myErr struct{}
+
+func (myErr) Error() string { return "my" }
+
+func assert[E error](err error) E {
+	if e, ok := any(err).(E); ok {
+		return e
+	}
+	var fallback E
+	_ = any(err).(error)
+	_ = any(err).(myErr)  // want "unchecked assertion of an error to myErr panics"
+	_ = any(fallback).(E) // want "unchecked assertion of an error to E panics"
+	_ = any(1).(int)
+	return any(err).(E) // want "unchecked assertion of an error to E panics"
+}
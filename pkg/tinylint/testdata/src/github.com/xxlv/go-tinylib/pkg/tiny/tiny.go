@@ -0,0 +1,25 @@
+// Package tiny is a stub of the real package for analyzer tests.
+package tiny
+
+import "context"
+
+type Result[T any, E error] struct {
+	value T
+	fault E
+}
+
+func Ok[T any, E error](value T) Result[T, E] { return Result[T, E]{value: value} }
+
+func (r Result[T, E]) UnwrapOrPanic() T { return r.value }
+
+func (r Result[T, E]) Then(fn func(T) Result[T, E]) Result[T, E] { return fn(r.value) }
+
+func AsyncThen[T any, E error](r Result[T, E], fn func(T) Result[T, E]) <-chan Result[T, E] {
+	ch := make(chan Result[T, E], 1)
+	ch <- r.Then(fn)
+	return ch
+}
+
+func AsyncThenWithContext[T any, E error](ctx context.Context, r Result[T, E], fn func(T) Result[T, E]) <-chan Result[T, E] {
+	return AsyncThen(r, fn)
+}
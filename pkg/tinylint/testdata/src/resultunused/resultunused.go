@@ -0,0 +1,13 @@
+package resultunused
+
+import "github.com/xxlv/go-tinylib/pkg/tiny"
+
+func double(v int) tiny.Result[int, error] { return tiny.Ok[int, error](v * 2) }
+
+func unused() {
+	double(1)                           // want "result of double is never inspected"
+	tiny.Ok[int, error](1).Then(double) // want "result of Then is never inspected"
+	_ = double(1)
+	r := double(2)
+	_ = r.Then(double)
+}
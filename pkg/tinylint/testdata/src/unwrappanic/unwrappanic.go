@@ -0,0 +1,9 @@
+package unwrappanic
+
+import "github.com/xxlv/go-tinylib/pkg/tiny"
+
+func double(v int) tiny.Result[int, error] { return tiny.Ok[int, error](v * 2) }
+
+func unwrap() int {
+	return double(1).UnwrapOrPanic() // want "UnwrapOrPanic outside tests and package main"
+}
@@ -0,0 +1,9 @@
+package unwrappanic
+
+import "testing"
+
+func TestDouble(t *testing.T) {
+	if double(1).UnwrapOrPanic() != 2 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/xxlv/go-tinylib/pkg/tiny"
+
+func main() {
+	println(tiny.Ok[int, error](1).UnwrapOrPanic())
+}
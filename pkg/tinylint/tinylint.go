@@ -0,0 +1,293 @@
+// Package tinylint provides go/analysis analyzers that catch common misuses of tiny.Result.
+package tinylint
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// tinyPath is the import path of the tiny package.
+const tinyPath = "github.com/xxlv/go-tinylib/pkg/tiny"
+
+// Analyzers lists every analyzer of the package, in the order tinylint runs them.
+var Analyzers = []*analysis.Analyzer{ResultUnused, UnwrapPanic, AsyncDropped, ErrAssert}
+
+// ResultUnused reports calls whose tiny.Result is thrown away without being inspected.
+var ResultUnused = &analysis.Analyzer{
+	Name:     "resultunused",
+	Doc:      "report tiny.Result values that are created but never inspected",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runResultUnused,
+}
+
+// UnwrapPanic reports calls to Result.UnwrapOrPanic outside tests and package main.
+var UnwrapPanic = &analysis.Analyzer{
+	Name:     "unwrappanic",
+	Doc:      "report calls to tiny.Result.UnwrapOrPanic outside tests and package main",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runUnwrapPanic,
+}
+
+// AsyncDropped reports channels returned by tiny's Async functions that are never received from:
+// discarded outright, or stored in a local variable that is neither received from nor passed on.
+var AsyncDropped = &analysis.Analyzer{
+	Name:     "asyncdropped",
+	Doc:      "report channels returned by tiny.AsyncThen* that are never received from",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runAsyncDropped,
+}
+
+// ErrAssert reports unchecked type assertions of an error converted to any, such as any(err).(E),
+// which panic whenever the error has another type.
+var ErrAssert = &analysis.Analyzer{
+	Name:     "errassert",
+	Doc:      "report unchecked any(err).(T) assertions to a concrete or type parameter error type",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runErrAssert,
+}
+
+func runResultUnused(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.ExprStmt)(nil)}, func(n ast.Node) {
+		call, ok := ast.Unparen(n.(*ast.ExprStmt).X).(*ast.CallExpr)
+		if ok && isResult(pass.TypesInfo.TypeOf(call)) {
+			pass.Reportf(call.Pos(), "result of %s is never inspected", callName(call))
+		}
+	})
+	return nil, nil
+}
+
+func runUnwrapPanic(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Name() == "main" {
+		return nil, nil
+	}
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "UnwrapOrPanic" || !isResult(pass.TypesInfo.TypeOf(sel.X)) {
+			return
+		}
+		if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), "_test.go") {
+			return
+		}
+		pass.Reportf(sel.Sel.Pos(), "UnwrapOrPanic outside tests and package main; handle the Failure instead")
+	})
+	return nil, nil
+}
+
+func runAsyncDropped(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	// stored maps each local variable holding an Async channel to the calls assigned to it.
+	stored := make(map[*types.Var][]*ast.CallExpr)
+	assign := func(lhs, rhs ast.Expr) {
+		if isBlank(lhs) {
+			reportAsync(pass, rhs)
+			return
+		}
+		call := asyncCall(pass, rhs)
+		id, ok := lhs.(*ast.Ident)
+		if call == nil || !ok {
+			return
+		}
+		if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok && v.Parent() != pass.Pkg.Scope() {
+			stored[v] = append(stored[v], call)
+		}
+	}
+	ins.Preorder([]ast.Node{(*ast.ExprStmt)(nil), (*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			reportAsync(pass, n.X)
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					assign(lhs, n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i, name := range n.Names {
+					assign(name, n.Values[i])
+				}
+			}
+		}
+	})
+	if len(stored) == 0 {
+		return nil, nil
+	}
+
+	ins.WithStack([]ast.Node{(*ast.Ident)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		v, ok := pass.TypesInfo.Uses[n.(*ast.Ident)].(*types.Var)
+		if ok && stored[v] != nil && receivesOrEscapes(n.(*ast.Ident), stack) {
+			delete(stored, v)
+		}
+		return true
+	})
+	for _, calls := range stored {
+		for _, call := range calls {
+			reportAsync(pass, call)
+		}
+	}
+	return nil, nil
+}
+
+// receivesOrEscapes reports whether the use id of a channel variable receives from it, or hands it
+// to other code that may, rather than overwriting or discarding it.
+func receivesOrEscapes(id *ast.Ident, stack []ast.Node) bool {
+	if len(stack) < 2 {
+		return true
+	}
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.AssignStmt:
+		for i, lhs := range parent.Lhs {
+			if lhs == id {
+				return false
+			}
+			if len(parent.Lhs) == len(parent.Rhs) && parent.Rhs[i] == id && isBlank(lhs) {
+				return false
+			}
+		}
+	case *ast.ValueSpec:
+		for i, value := range parent.Values {
+			if value == id && len(parent.Names) == len(parent.Values) && isBlank(parent.Names[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// asyncCall returns expr as a call to one of tiny's Async functions, or nil.
+func asyncCall(pass *analysis.Pass, expr ast.Expr) *ast.CallExpr {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	fn := calledFunc(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != tinyPath || !strings.HasPrefix(fn.Name(), "Async") {
+		return nil
+	}
+	return call
+}
+
+// reportAsync reports expr if it is a call to one of tiny's Async functions.
+func reportAsync(pass *analysis.Pass, expr ast.Expr) {
+	if call := asyncCall(pass, expr); call != nil {
+		pass.Reportf(call.Pos(), "channel returned by %s is never received from", calledFunc(pass.TypesInfo, call).Name())
+	}
+}
+
+func runErrAssert(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.WithStack([]ast.Node{(*ast.TypeAssertExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		assert := n.(*ast.TypeAssertExpr)
+		if assert.Type == nil || isCommaOk(assert, stack) {
+			return true
+		}
+		conv, ok := ast.Unparen(assert.X).(*ast.CallExpr)
+		if !ok || len(conv.Args) != 1 {
+			return true
+		}
+		if tv, ok := pass.TypesInfo.Types[conv.Fun]; !ok || !tv.IsType() || !types.IsInterface(tv.Type) {
+			return true
+		}
+		if !implementsError(pass.TypesInfo.TypeOf(conv.Args[0])) {
+			return true
+		}
+		target := pass.TypesInfo.TypeOf(assert.Type)
+		if _, isParam := target.(*types.TypeParam); !isParam && types.IsInterface(target) {
+			return true
+		}
+		pass.Reportf(assert.Pos(), "unchecked assertion of an error to %s panics for any other error type; use errors.As or tiny.AdaptError", types.TypeString(target, types.RelativeTo(pass.Pkg)))
+		return true
+	})
+	return nil, nil
+}
+
+// isResult reports whether t is an instance of tiny.Result.
+func isResult(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == tinyPath && obj.Name() == "Result"
+}
+
+// implementsError reports whether t is, or satisfies, the error interface.
+func implementsError(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	errType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.Implements(t, errType)
+}
+
+// isCommaOk reports whether assert is used in a v, ok form, which cannot panic.
+func isCommaOk(assert *ast.TypeAssertExpr, stack []ast.Node) bool {
+	if len(stack) < 2 {
+		return false
+	}
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.AssignStmt:
+		return len(parent.Lhs) == 2 && len(parent.Rhs) == 1
+	case *ast.ValueSpec:
+		return len(parent.Names) == 2 && len(parent.Values) == 1
+	}
+	return false
+}
+
+// calledFunc returns the function or method called by call, or nil for other calls.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	fun := ast.Unparen(call.Fun)
+	if idx, ok := fun.(*ast.IndexExpr); ok {
+		fun = idx.X
+	} else if idx, ok := fun.(*ast.IndexListExpr); ok {
+		fun = idx.X
+	}
+	var id *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// callName returns a short description of the called function for diagnostics.
+func callName(call *ast.CallExpr) string {
+	fun := ast.Unparen(call.Fun)
+	if idx, ok := fun.(*ast.IndexExpr); ok {
+		fun = idx.X
+	} else if idx, ok := fun.(*ast.IndexListExpr); ok {
+		fun = idx.X
+	}
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	}
+	return "call"
+}
+
+// isBlank reports whether expr is the blank identifier.
+func isBlank(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "_"
+}
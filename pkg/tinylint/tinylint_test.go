@@ -0,0 +1,26 @@
+package tinylint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzers(t *testing.T) {
+	tests := []struct {
+		analyzer *analysis.Analyzer
+		pkgs     []string
+	}{
+		{ResultUnused, []string{"resultunused"}},
+		{UnwrapPanic, []string{"unwrappanic", "unwrappaniccli"}},
+		{AsyncDropped, []string{"asyncdropped"}},
+		{ErrAssert, []string{"errassert"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.analyzer.Name, func(t *testing.T) {
+			analysistest.Run(t, analysistest.TestData(), tt.analyzer, tt.pkgs...)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package tinymetrics provides implementations of tiny.Recorder: ExpvarRecorder publishes metrics with expvar,
+// so they are served at /debug/vars with no external dependency, and MemoryRecorder keeps them in memory for tests.
+package tinymetrics
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// DefaultBuckets are the upper bounds of the latency histogram buckets of an ExpvarRecorder created without any.
+var DefaultBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 500 * time.Millisecond, time.Second, 5 * time.Second, 10 * time.Second,
+}
+
+var _ tiny.Recorder = (*ExpvarRecorder)(nil)
+
+// ExpvarRecorder is a tiny.Recorder publishing one expvar.Map, holding a map per operation:
+//
+//	{"getUser": {"ok": 120, "fail": 3, "in_flight": 0, "latency": {"1ms": 80, "5ms": 40, ..., "+Inf": 0}}}
+//
+// Each latency bucket counts the calls that took at most its bound and more than the previous one;
+// "+Inf" counts the slower ones. An ExpvarRecorder must be created with NewExpvarRecorder.
+type ExpvarRecorder struct {
+	root    *expvar.Map
+	buckets []time.Duration
+
+	mu  sync.Mutex // Serializes the creation of operation maps.
+	ops map[string]*expvar.Map
+}
+
+// NewExpvarRecorder creates an ExpvarRecorder published as the expvar name, with latency buckets bounded by buckets,
+// in increasing order, or DefaultBuckets if nil. Like expvar.Publish, it panics if name is already published.
+func NewExpvarRecorder(name string, buckets []time.Duration) *ExpvarRecorder {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	return &ExpvarRecorder{root: expvar.NewMap(name), buckets: buckets, ops: make(map[string]*expvar.Map)}
+}
+
+// Observe counts a call of name as ok or fail and adds its latency to the histogram.
+func (r *ExpvarRecorder) Observe(name string, elapsed time.Duration, ok bool) {
+	op := r.op(name)
+	if ok {
+		op.Add("ok", 1)
+	} else {
+		op.Add("fail", 1)
+	}
+	op.Get("latency").(*expvar.Map).Add(r.bucket(elapsed), 1)
+}
+
+// InFlight adds delta to the in_flight gauge of name.
+func (r *ExpvarRecorder) InFlight(name string, delta int) {
+	r.op(name).Add("in_flight", int64(delta))
+}
+
+// op returns the map of name, creating it with every key at zero on first use.
+func (r *ExpvarRecorder) op(name string) *expvar.Map {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if op, ok := r.ops[name]; ok {
+		return op
+	}
+	op := new(expvar.Map).Init()
+	for _, key := range []string{"ok", "fail", "in_flight"} {
+		op.Add(key, 0)
+	}
+	latency := new(expvar.Map).Init()
+	for _, b := range r.buckets {
+		latency.Add(b.String(), 0)
+	}
+	latency.Add("+Inf", 0)
+	op.Set("latency", latency)
+	r.root.Set(name, op)
+	r.ops[name] = op
+	return op
+}
+
+// bucket returns the key of the latency bucket for elapsed.
+func (r *ExpvarRecorder) bucket(elapsed time.Duration) string {
+	for _, b := range r.buckets {
+		if elapsed <= b {
+			return b.String()
+		}
+	}
+	return "+Inf"
+}
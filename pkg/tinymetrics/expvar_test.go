@@ -0,0 +1,62 @@
+package tinymetrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// published counts the expvar names published by the tests, which cannot be published twice in a process.
+var published atomic.Int32
+
+// uniqueName returns an expvar name not yet used by this process, so that the tests can run with -count.
+func uniqueName(t *testing.T) string {
+	return fmt.Sprintf("%s_%d", t.Name(), published.Add(1))
+}
+
+func TestExpvarRecorder(t *testing.T) {
+	name := uniqueName(t)
+	rec := NewExpvarRecorder(name, []time.Duration{time.Millisecond, 10 * time.Millisecond})
+	rec.Observe("op", 500*time.Microsecond, true)
+	rec.Observe("op", 5*time.Millisecond, true)
+	rec.Observe("op", time.Second, false)
+	rec.InFlight("AsyncThen", 1)
+	rec.InFlight("AsyncThen", 1)
+	rec.InFlight("AsyncThen", -1)
+
+	var got map[string]struct {
+		Ok       int            `json:"ok"`
+		Fail     int            `json:"fail"`
+		InFlight int            `json:"in_flight"`
+		Latency  map[string]int `json:"latency"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	op := got["op"]
+	if op.Ok != 2 || op.Fail != 1 || op.InFlight != 0 {
+		t.Errorf("op = %+v, want 2 ok and 1 fail", op)
+	}
+	want := map[string]int{"1ms": 1, "10ms": 1, "+Inf": 1}
+	for bucket, n := range want {
+		if op.Latency[bucket] != n {
+			t.Errorf("latency[%s] = %d, want %d (histogram %v)", bucket, op.Latency[bucket], n, op.Latency)
+		}
+	}
+	if got["AsyncThen"].InFlight != 1 {
+		t.Errorf("AsyncThen in_flight = %d, want 1", got["AsyncThen"].InFlight)
+	}
+}
+
+func TestExpvarRecorderDefaultBuckets(t *testing.T) {
+	name := uniqueName(t)
+	rec := NewExpvarRecorder(name, nil)
+	rec.Observe("op", 3*time.Second, true)
+	latency := expvar.Get(name).(*expvar.Map).Get("op").(*expvar.Map).Get("latency").(*expvar.Map)
+	if got := latency.Get("5s").String(); got != "1" {
+		t.Errorf("latency[5s] = %s, want 1", got)
+	}
+}
@@ -0,0 +1,87 @@
+package tinymetrics
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+var _ tiny.Recorder = (*MemoryRecorder)(nil)
+
+// OpStats are the metrics a MemoryRecorder holds for one operation.
+type OpStats struct {
+	Ok        int             // Successful calls.
+	Fail      int             // Failed calls.
+	Latencies []time.Duration // The latency of every call, in the order they finished.
+	InFlight  int             // Async calls started and not finished yet.
+}
+
+// MemoryRecorder is a tiny.Recorder keeping every observation in memory, for tests.
+// The zero value is ready to use.
+//
+// Example:
+//
+//	rec := &tinymetrics.MemoryRecorder{}
+//	tiny.SetRecorder(rec)
+//	t.Cleanup(func() { tiny.SetRecorder(nil) })
+//	getUser(ctx, 42)
+//	if got := rec.Stats("getUser"); got.Ok != 1 { ... }
+type MemoryRecorder struct {
+	mu  sync.Mutex
+	ops map[string]*OpStats
+}
+
+// Observe records a call of name.
+func (r *MemoryRecorder) Observe(name string, elapsed time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	op := r.op(name)
+	if ok {
+		op.Ok++
+	} else {
+		op.Fail++
+	}
+	op.Latencies = append(op.Latencies, elapsed)
+}
+
+// InFlight adds delta to the async calls of name in flight.
+func (r *MemoryRecorder) InFlight(name string, delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.op(name).InFlight += delta
+}
+
+// Stats returns a copy of the metrics recorded for name.
+func (r *MemoryRecorder) Stats(name string) OpStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	op, ok := r.ops[name]
+	if !ok {
+		return OpStats{}
+	}
+	stats := *op
+	stats.Latencies = slices.Clone(op.Latencies)
+	return stats
+}
+
+// Reset forgets everything recorded so far.
+func (r *MemoryRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ops = nil
+}
+
+// op returns the stats of name, creating them on first use. r.mu must be held.
+func (r *MemoryRecorder) op(name string) *OpStats {
+	if r.ops == nil {
+		r.ops = make(map[string]*OpStats)
+	}
+	op, ok := r.ops[name]
+	if !ok {
+		op = &OpStats{}
+		r.ops[name] = op
+	}
+	return op
+}
@@ -0,0 +1,55 @@
+package tinymetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+func TestMemoryRecorder(t *testing.T) {
+	rec := &MemoryRecorder{}
+	rec.Observe("op", time.Millisecond, true)
+	rec.Observe("op", 2*time.Millisecond, false)
+	rec.InFlight("op", 1)
+
+	got := rec.Stats("op")
+	if got.Ok != 1 || got.Fail != 1 || got.InFlight != 1 || len(got.Latencies) != 2 || got.Latencies[1] != 2*time.Millisecond {
+		t.Errorf("Stats() = %+v", got)
+	}
+	got.Latencies[0] = time.Hour
+	if rec.Stats("op").Latencies[0] != time.Millisecond {
+		t.Errorf("Stats() should return a copy of the latencies")
+	}
+	if got := rec.Stats("unknown"); got.Ok != 0 || got.Latencies != nil {
+		t.Errorf("Stats() of an unknown operation = %+v, want zero", got)
+	}
+
+	rec.Reset()
+	if got := rec.Stats("op"); got.Ok != 0 {
+		t.Errorf("Stats() after Reset() = %+v, want zero", got)
+	}
+}
+
+func TestMemoryRecorderWithInstrument(t *testing.T) {
+	rec := &MemoryRecorder{}
+	tiny.SetRecorder(rec)
+	t.Cleanup(func() { tiny.SetRecorder(nil) })
+
+	errDown := errors.New("down")
+	op := tiny.Instrument("fetch", func(_ context.Context, up bool) tiny.Result[string, error] {
+		if !up {
+			return tiny.Fail[string](errDown)
+		}
+		return tiny.Ok[string, error]("page")
+	})
+	op(context.Background(), true)
+	op(context.Background(), false)
+	<-tiny.AsyncThen(tiny.Ok[int, error](1), func(x int) tiny.Result[int, error] { return tiny.Ok[int, error](x) })
+
+	if got := rec.Stats("fetch"); got.Ok != 1 || got.Fail != 1 || len(got.Latencies) != 2 {
+		t.Errorf("Stats(fetch) = %+v, want one ok and one fail", got)
+	}
+}
@@ -0,0 +1,106 @@
+// Package tinysql provides database/sql helpers that return tiny.Result and manage transactions.
+package tinysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// Scanner is implemented by *sql.Row and *sql.Rows.
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+// Queryer is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// TxBeginner is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// QueryRow runs query and scans its first row with scan.
+// If the query matches no rows, it returns a Failure Result with sql.ErrNoRows.
+//
+// Example:
+//
+//	name := QueryRow(ctx, db, func(s Scanner) (string, error) {
+//	    var name string
+//	    return name, s.Scan(&name)
+//	}, "SELECT name FROM users WHERE id = ?", id)
+func QueryRow[T any](ctx context.Context, db Queryer, scan func(Scanner) (T, error), query string, args ...any) tiny.Result[T, error] {
+	value, err := scan(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return tiny.Fail[T](err)
+	}
+	return tiny.Ok[T, error](value)
+}
+
+// Query runs query and scans every row with scan, returning the values in order.
+// It fails with the first error from the query, a scan or the iteration, and always closes the rows.
+func Query[T any](ctx context.Context, db Queryer, scan func(Scanner) (T, error), query string, args ...any) tiny.Result[[]T, error] {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return tiny.Fail[[]T](err)
+	}
+	defer rows.Close()
+
+	var values []T
+	for rows.Next() {
+		value, err := scan(rows)
+		if err != nil {
+			return tiny.Fail[[]T](err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return tiny.Fail[[]T](err)
+	}
+	return tiny.Ok[[]T, error](values)
+}
+
+// InTx runs fn inside a transaction started on db.
+// The transaction is committed if fn returns a Success and rolled back if it returns a Failure or panics;
+// a panic is re-raised after the rollback.
+// If the rollback itself fails, the Failure holds both errors joined with errors.Join.
+// The Result holds a plain error, so that database errors never need converting into E:
+// a Failure of fn keeps its error, which errors.As still finds in the chain.
+//
+// Example:
+//
+//	id := InTx(ctx, db, func(tx *sql.Tx) tiny.Result[int64, error] {
+//	    return insertOrder(ctx, tx, order).Then(func(id int64) tiny.Result[int64, error] {
+//	        return reserveStock(ctx, tx, id)
+//	    })
+//	})
+func InTx[T any, E error](ctx context.Context, db TxBeginner, fn func(*sql.Tx) tiny.Result[T, E]) tiny.Result[T, error] {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return tiny.Fail[T](fmt.Errorf("begin: %w", err))
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	result := tiny.MapErr(fn(tx), func(e E) error { return e })
+	if result.IsErr() {
+		if err := tx.Rollback(); err != nil {
+			return tiny.Fail[T](errors.Join(result.Unwrap(), fmt.Errorf("rollback: %w", err)))
+		}
+		return result
+	}
+	if err := tx.Commit(); err != nil {
+		return tiny.Fail[T](fmt.Errorf("commit: %w", err))
+	}
+	return result
+}
@@ -0,0 +1,245 @@
+package tinysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// stubDB is an in-memory driver.Connector that serves a fixed users table and records transaction outcomes.
+type stubDB struct {
+	beginErr    error
+	commitErr   error
+	rollbackErr error
+	commits     int
+	rollbacks   int
+}
+
+func (d *stubDB) Connect(context.Context) (driver.Conn, error) { return &stubConn{db: d}, nil }
+func (d *stubDB) Driver() driver.Driver                        { return nil }
+
+type stubConn struct{ db *stubDB }
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) { return &stubStmt{query: query}, nil }
+func (c *stubConn) Close() error                              { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) {
+	if c.db.beginErr != nil {
+		return nil, c.db.beginErr
+	}
+	return &stubTx{db: c.db}, nil
+}
+
+type stubTx struct{ db *stubDB }
+
+func (t *stubTx) Commit() error {
+	t.db.commits++
+	return t.db.commitErr
+}
+
+func (t *stubTx) Rollback() error {
+	t.db.rollbacks++
+	return t.db.rollbackErr
+}
+
+type stubStmt struct{ query string }
+
+func (s *stubStmt) Close() error  { return nil }
+func (s *stubStmt) NumInput() int { return -1 }
+
+func (s *stubStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stubStmt) Query([]driver.Value) (driver.Rows, error) {
+	switch {
+	case strings.Contains(s.query, "broken"):
+		return nil, errors.New("syntax error")
+	case strings.Contains(s.query, "nobody"):
+		return &stubRows{}, nil
+	default:
+		return &stubRows{data: [][]driver.Value{{int64(1), "ada"}, {int64(2), "bob"}}}, nil
+	}
+}
+
+type stubRows struct {
+	data [][]driver.Value
+	next int
+}
+
+func (r *stubRows) Columns() []string { return []string{"id", "name"} }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.next])
+	r.next++
+	return nil
+}
+
+type testUser struct {
+	ID   int64
+	Name string
+}
+
+func scanUser(s Scanner) (testUser, error) {
+	var u testUser
+	err := s.Scan(&u.ID, &u.Name)
+	return u, err
+}
+
+func openStub(t *testing.T) (*sql.DB, *stubDB) {
+	stub := &stubDB{}
+	db := sql.OpenDB(stub)
+	t.Cleanup(func() { db.Close() })
+	return db, stub
+}
+
+func TestQueryRow(t *testing.T) {
+	db, _ := openStub(t)
+	ctx := context.Background()
+
+	got := QueryRow(ctx, db, scanUser, "SELECT id, name FROM users")
+	if got.OrElse(testUser{}) != (testUser{ID: 1, Name: "ada"}) {
+		t.Errorf("QueryRow() = %v, want first user", got)
+	}
+
+	none := QueryRow(ctx, db, scanUser, "SELECT id, name FROM nobody")
+	if !errors.Is(none.Unwrap(), sql.ErrNoRows) {
+		t.Errorf("QueryRow() = %v, want sql.ErrNoRows", none)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	db, _ := openStub(t)
+	ctx := context.Background()
+
+	got := Query(ctx, db, scanUser, "SELECT id, name FROM users")
+	users := got.UnwrapOrPanic()
+	if len(users) != 2 || users[1].Name != "bob" {
+		t.Errorf("Query() = %v, want both users", got)
+	}
+
+	broken := Query(ctx, db, scanUser, "SELECT broken")
+	if broken.IsOk() {
+		t.Errorf("Query() on a failing query should fail, got %v", broken)
+	}
+}
+
+func TestInTx(t *testing.T) {
+	errStep := errors.New("step failed")
+	errRollback := errors.New("connection lost")
+	tests := []struct {
+		name          string
+		rollbackErr   error
+		fn            func(*sql.Tx) tiny.Result[int, error]
+		wantCommits   int
+		wantRollbacks int
+		wantErrs      []error
+	}{
+		{
+			name:        "commits on success",
+			fn:          func(*sql.Tx) tiny.Result[int, error] { return tiny.Ok[int, error](1) },
+			wantCommits: 1,
+		},
+		{
+			name:          "rolls back on failure",
+			fn:            func(*sql.Tx) tiny.Result[int, error] { return tiny.Fail[int](errStep) },
+			wantRollbacks: 1,
+			wantErrs:      []error{errStep},
+		},
+		{
+			name:          "keeps both errors when rollback fails",
+			rollbackErr:   errRollback,
+			fn:            func(*sql.Tx) tiny.Result[int, error] { return tiny.Fail[int](errStep) },
+			wantRollbacks: 1,
+			wantErrs:      []error{errStep, errRollback},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, stub := openStub(t)
+			stub.rollbackErr = tt.rollbackErr
+			got := InTx(context.Background(), db, tt.fn)
+			if stub.commits != tt.wantCommits || stub.rollbacks != tt.wantRollbacks {
+				t.Errorf("InTx() commits = %d, rollbacks = %d, want %d, %d", stub.commits, stub.rollbacks, tt.wantCommits, tt.wantRollbacks)
+			}
+			if len(tt.wantErrs) == 0 && got.IsErr() {
+				t.Errorf("InTx() = %v, want success", got)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(got.Unwrap(), want) {
+					t.Errorf("InTx() error = %v, want it to contain %v", got.Unwrap(), want)
+				}
+			}
+		})
+	}
+}
+
+type stepError struct{ step string }
+
+func (e *stepError) Error() string { return e.step + " failed" }
+
+func TestInTxConcreteError(t *testing.T) {
+	errDB := errors.New("connection lost")
+	tests := []struct {
+		name      string
+		beginErr  error
+		commitErr error
+		fnErr     *stepError
+		wantErrs  []error
+		wantStep  bool
+	}{
+		{name: "begin fails", beginErr: errDB, wantErrs: []error{errDB}},
+		{name: "commit fails", commitErr: errDB, wantErrs: []error{errDB}},
+		{name: "fn fails", fnErr: &stepError{step: "reserve"}, wantStep: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, stub := openStub(t)
+			stub.beginErr, stub.commitErr = tt.beginErr, tt.commitErr
+			got := InTx(context.Background(), db, func(*sql.Tx) tiny.Result[int, *stepError] {
+				if tt.fnErr != nil {
+					return tiny.Fail[int](tt.fnErr)
+				}
+				return tiny.Ok[int, *stepError](1)
+			})
+			if !got.IsErr() {
+				t.Fatalf("InTx() = %v, want a Failure", got)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(got.Unwrap(), want) {
+					t.Errorf("InTx() error = %v, want it to contain %v", got.Unwrap(), want)
+				}
+			}
+			var step *stepError
+			if tt.wantStep && (!errors.As(got.Unwrap(), &step) || step != tt.fnErr) {
+				t.Errorf("InTx() error = %v, want fn's *stepError", got.Unwrap())
+			}
+		})
+	}
+}
+
+func TestInTxPanic(t *testing.T) {
+	db, stub := openStub(t)
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("InTx() should re-raise the panic, got %v", r)
+		}
+		if stub.rollbacks != 1 || stub.commits != 0 {
+			t.Errorf("InTx() should roll back on panic, commits = %d, rollbacks = %d", stub.commits, stub.rollbacks)
+		}
+	}()
+	InTx(context.Background(), db, func(*sql.Tx) tiny.Result[int, error] {
+		panic("boom")
+	})
+}
@@ -0,0 +1,78 @@
+// Package tinytest provides test assertions for tiny.Result values.
+// Every assertion stops the test with t.Fatalf when it fails, since the code that follows usually depends on it.
+package tinytest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// AssertOk fails the test unless r is a Success, and returns its value.
+//
+// Example:
+//
+//	user := tinytest.AssertOk(t, repo.Find(ctx, 42))
+func AssertOk[T any, E error](t testing.TB, r tiny.Result[T, E]) T {
+	t.Helper()
+	if !r.IsOk() {
+		t.Fatalf("want Ok, got %v", r)
+	}
+	var zero T
+	return r.OrElse(zero)
+}
+
+// AssertErr fails the test unless r is a Failure, and returns its error.
+func AssertErr[T any, E error](t testing.TB, r tiny.Result[T, E]) E {
+	t.Helper()
+	if !r.IsErr() {
+		t.Fatalf("want Err, got %v", r)
+	}
+	return r.Unwrap()
+}
+
+// AssertErrIs fails the test unless r is a Failure whose error matches target with errors.Is.
+func AssertErrIs[T any, E error](t testing.TB, r tiny.Result[T, E], target error) {
+	t.Helper()
+	err := AssertErr(t, r)
+	if !errors.Is(err, target) {
+		t.Fatalf("want Err matching %v, got %v", target, r)
+	}
+}
+
+// AssertErrAs fails the test unless r is a Failure whose error matches X with errors.As, and returns the match.
+//
+// Example:
+//
+//	notFound := tinytest.AssertErrAs[*tiny.NotFoundError](t, loader.Load(ctx, 7))
+func AssertErrAs[X any, T any, E error](t testing.TB, r tiny.Result[T, E]) X {
+	t.Helper()
+	err := AssertErr(t, r)
+	var target X
+	if !errors.As(err, &target) {
+		t.Fatalf("want Err of type %v, got %v (%T)", reflect.TypeFor[X](), r, err)
+	}
+	return target
+}
+
+// AssertEventuallyOk waits up to timeout for a Result from ch, such as the channel of AsyncThen,
+// fails the test unless it arrives and is a Success, and returns its value.
+func AssertEventuallyOk[T any, E error](t testing.TB, ch <-chan tiny.Result[T, E], timeout time.Duration) T {
+	t.Helper()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r, ok := <-ch:
+		if !ok {
+			t.Fatalf("want Ok, got a closed channel")
+		}
+		return AssertOk(t, r)
+	case <-timer.C:
+		t.Fatalf("want Ok within %v, got no Result", timeout)
+	}
+	var zero T
+	return zero
+}
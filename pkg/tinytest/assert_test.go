@@ -0,0 +1,136 @@
+package tinytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// fakeT records the failure of an assertion. Fatalf ends the calling goroutine, as testing.T does.
+type fakeT struct {
+	testing.TB
+	failure string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failure = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// check runs assert with a fakeT and returns its failure message, or "" if it passed.
+func check(assert func(t testing.TB)) string {
+	f := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert(f)
+	}()
+	<-done
+	return f.failure
+}
+
+type codeError struct{ code int }
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func TestAssertions(t *testing.T) {
+	errBoom := errors.New("boom")
+	wrapped := fmt.Errorf("wrapped: %w", &codeError{code: 7})
+	ok := tiny.Ok[int, error](42)
+	fail := tiny.Fail[int](errBoom)
+
+	tests := []struct {
+		name        string
+		assert      func(t testing.TB)
+		wantFailure string
+	}{
+		{
+			name: "AssertOk passes",
+			assert: func(t testing.TB) {
+				if got := AssertOk(t, ok); got != 42 {
+					panic(fmt.Sprintf("AssertOk() = %d, want 42", got))
+				}
+			},
+		},
+		{name: "AssertOk fails", assert: func(t testing.TB) { AssertOk(t, fail) }, wantFailure: "want Ok, got Err(boom)"},
+		{
+			name: "AssertErr passes",
+			assert: func(t testing.TB) {
+				if got := AssertErr(t, fail); got != errBoom {
+					panic(fmt.Sprintf("AssertErr() = %v, want boom", got))
+				}
+			},
+		},
+		{name: "AssertErr fails", assert: func(t testing.TB) { AssertErr(t, ok) }, wantFailure: "want Err, got Ok(42)"},
+		{name: "AssertErrIs passes", assert: func(t testing.TB) { AssertErrIs(t, fail.Wrap("ctx"), errBoom) }},
+		{
+			name:        "AssertErrIs fails on another error",
+			assert:      func(t testing.TB) { AssertErrIs(t, fail, context.Canceled) },
+			wantFailure: "want Err matching context canceled, got Err(boom)",
+		},
+		{name: "AssertErrIs fails on Ok", assert: func(t testing.TB) { AssertErrIs(t, ok, errBoom) }, wantFailure: "want Err, got Ok(42)"},
+		{
+			name: "AssertErrAs passes",
+			assert: func(t testing.TB) {
+				if got := AssertErrAs[*codeError](t, tiny.Fail[int](wrapped)); got.code != 7 {
+					panic(fmt.Sprintf("AssertErrAs() = %v, want code 7", got))
+				}
+			},
+		},
+		{
+			name:        "AssertErrAs fails",
+			assert:      func(t testing.TB) { AssertErrAs[*codeError](t, fail) },
+			wantFailure: "want Err of type *tinytest.codeError, got Err(boom) (*errors.errorString)",
+		},
+		{
+			name: "AssertEventuallyOk passes",
+			assert: func(t testing.TB) {
+				ch := tiny.AsyncThen(ok, func(v int) tiny.Result[int, error] { return tiny.Ok[int, error](v + 1) })
+				if got := AssertEventuallyOk(t, ch, time.Second); got != 43 {
+					panic(fmt.Sprintf("AssertEventuallyOk() = %d, want 43", got))
+				}
+			},
+		},
+		{
+			name:        "AssertEventuallyOk fails on Err",
+			assert:      func(t testing.TB) { AssertEventuallyOk(t, tiny.AsyncThen(fail, nil), time.Second) },
+			wantFailure: "want Ok, got Err(boom)",
+		},
+		{
+			name: "AssertEventuallyOk times out",
+			assert: func(t testing.TB) {
+				AssertEventuallyOk(t, make(chan tiny.Result[int, error]), time.Millisecond)
+			},
+			wantFailure: "want Ok within 1ms, got no Result",
+		},
+		{
+			name: "AssertEventuallyOk fails on closed channel",
+			assert: func(t testing.TB) {
+				ch := make(chan tiny.Result[int, error])
+				close(ch)
+				AssertEventuallyOk(t, ch, time.Second)
+			},
+			wantFailure: "got a closed channel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := check(tt.assert)
+			if tt.wantFailure == "" && got != "" {
+				t.Errorf("assertion failed with %q, want it to pass", got)
+			}
+			if tt.wantFailure != "" && !strings.Contains(got, tt.wantFailure) {
+				t.Errorf("assertion failure = %q, want %q", got, tt.wantFailure)
+			}
+		})
+	}
+}
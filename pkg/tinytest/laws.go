@@ -0,0 +1,139 @@
+package tinytest
+
+import (
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+// Laws checks Then, Map and MapErr, or replacements for them, against the functor and monad laws with testing/quick.
+// The Results the laws start from come from Result.Generate, unless Config.Values supplies its own generator,
+// and the functions they combine are picked from Then, Map and MapErr, which must be pure.
+// The laws that need functions of a kind that is not supplied are skipped, as are the laws of Map
+// when E cannot be nil, since tiny.Map treats every value of such an E as an error.
+//
+// Example:
+//
+//	tinytest.Laws[int, error]{
+//	    Then: []func(int) tiny.Result[int, error]{half, positive},
+//	    Map:  []func(int) int{double, negate},
+//	    Bind: myThen,
+//	}.Check(t)
+type Laws[T any, E error] struct {
+	Then   []func(T) tiny.Result[T, E] // Functions for the monad laws.
+	Map    []func(T) T                 // Functions for the functor laws of Map.
+	MapErr []func(E) E                 // Functions for the functor laws of MapErr.
+
+	// The implementations under test. Nil means the library's own Then, Map and MapErr.
+	Bind     func(tiny.Result[T, E], func(T) tiny.Result[T, E]) tiny.Result[T, E]
+	MapFn    func(tiny.Result[T, E], func(T) (T, E)) tiny.Result[T, E]
+	MapErrFn func(tiny.Result[T, E], func(E) E) tiny.Result[T, E]
+	Equal    func(a, b tiny.Result[T, E]) bool // Nil means equalResults.
+	Config   *quick.Config                     // Passed to quick.Check.
+}
+
+// equalResults reports whether a and b have the same state, value and error, compared with reflect.DeepEqual.
+// Unlike reflect.DeepEqual on the Results themselves, it ignores where a Failure was created,
+// so the laws still hold with tiny.EnableStackTraces on.
+func equalResults[T any, E error](a, b tiny.Result[T, E]) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.IsOk() != b.IsOk() {
+		return false
+	}
+	var zero T
+	return reflect.DeepEqual(a.OrElse(zero), b.OrElse(zero)) && reflect.DeepEqual(a.Unwrap(), b.Unwrap())
+}
+
+// nillable reports whether E can be nil, so that a function returning (T, E) can report no error.
+func nillable[E error]() bool {
+	switch reflect.TypeFor[E]().Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
+
+// Property is a law in the form quick.Check expects: a function of random arguments that reports whether the law holds.
+type Property struct {
+	Name string
+	Fn   any
+}
+
+// Properties returns the laws l can check, named after the law.
+func (l Laws[T, E]) Properties() []Property {
+	bind, mapFn, mapErr, equal := l.Bind, l.MapFn, l.MapErrFn, l.Equal
+	if bind == nil {
+		bind = func(r tiny.Result[T, E], fn func(T) tiny.Result[T, E]) tiny.Result[T, E] { return r.Then(fn) }
+	}
+	if mapFn == nil {
+		mapFn = func(r tiny.Result[T, E], fn func(T) (T, E)) tiny.Result[T, E] { return tiny.Map(r, fn) }
+	}
+	if mapErr == nil {
+		mapErr = func(r tiny.Result[T, E], fn func(E) E) tiny.Result[T, E] { return tiny.MapErr(r, fn) }
+	}
+	if equal == nil {
+		equal = equalResults[T, E]
+	}
+	pure := func(fn func(T) T) func(T) (T, E) {
+		return func(v T) (T, E) {
+			var noErr E
+			return fn(v), noErr
+		}
+	}
+
+	var props []Property
+	if n := uint(len(l.Then)); n > 0 {
+		props = append(props,
+			Property{"left identity", func(a T, i uint) bool {
+				f := l.Then[i%n]
+				return equal(bind(tiny.Ok[T, E](a), f), f(a))
+			}},
+			Property{"right identity", func(m tiny.Result[T, E]) bool {
+				return equal(bind(m, tiny.Ok[T, E]), m)
+			}},
+			Property{"associativity", func(m tiny.Result[T, E], i, j uint) bool {
+				f, g := l.Then[i%n], l.Then[j%n]
+				return equal(bind(bind(m, f), g), bind(m, func(v T) tiny.Result[T, E] { return bind(f(v), g) }))
+			}},
+		)
+	}
+	if n := uint(len(l.Map)); n > 0 && nillable[E]() {
+		props = append(props,
+			Property{"map identity", func(m tiny.Result[T, E]) bool {
+				return equal(mapFn(m, pure(func(v T) T { return v })), m)
+			}},
+			Property{"map composition", func(m tiny.Result[T, E], i, j uint) bool {
+				f, g := l.Map[i%n], l.Map[j%n]
+				return equal(mapFn(mapFn(m, pure(f)), pure(g)), mapFn(m, pure(func(v T) T { return g(f(v)) })))
+			}},
+		)
+	}
+	if n := uint(len(l.MapErr)); n > 0 {
+		props = append(props,
+			Property{"map error identity", func(m tiny.Result[T, E]) bool {
+				return equal(mapErr(m, func(e E) E { return e }), m)
+			}},
+			Property{"map error composition", func(m tiny.Result[T, E], i, j uint) bool {
+				f, g := l.MapErr[i%n], l.MapErr[j%n]
+				return equal(mapErr(mapErr(m, f), g), mapErr(m, func(e E) E { return g(f(e)) }))
+			}},
+		)
+	}
+	return props
+}
+
+// Check runs every law with quick.Check, each in its own subtest, and reports the arguments that break it.
+func (l Laws[T, E]) Check(t *testing.T) {
+	t.Helper()
+	for _, p := range l.Properties() {
+		t.Run(p.Name, func(t *testing.T) {
+			if err := quick.Check(p.Fn, l.Config); err != nil {
+				t.Errorf("%s does not hold: %v", p.Name, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package tinytest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	"github.com/xxlv/go-tinylib/pkg/tiny"
+)
+
+var errOdd = errors.New("odd")
+
+func intLaws() Laws[int, error] {
+	return Laws[int, error]{
+		Then: []func(int) tiny.Result[int, error]{
+			func(v int) tiny.Result[int, error] { return tiny.Ok[int, error](v / 2) },
+			func(v int) tiny.Result[int, error] {
+				if v%2 != 0 {
+					return tiny.Fail[int](errOdd)
+				}
+				return tiny.Ok[int, error](v)
+			},
+		},
+		Map:    []func(int) int{func(v int) int { return v * 2 }, func(v int) int { return -v }},
+		MapErr: []func(error) error{func(e error) error { return fmt.Errorf("wrapped: %w", e) }},
+	}
+}
+
+func TestLawsHold(t *testing.T) {
+	laws := intLaws()
+	if got := len(laws.Properties()); got != 7 {
+		t.Fatalf("Properties() returned %d laws, want 7", got)
+	}
+	laws.Check(t)
+}
+
+func TestLawsWithStackTraces(t *testing.T) {
+	tiny.EnableStackTraces(true)
+	t.Cleanup(func() { tiny.EnableStackTraces(false) })
+	intLaws().Check(t)
+}
+
+func TestLawsCatchBrokenCombinators(t *testing.T) {
+	tests := []struct {
+		name   string
+		broken func(l *Laws[int, error])
+		law    string
+	}{
+		{
+			name: "Then that swallows failures",
+			broken: func(l *Laws[int, error]) {
+				l.Bind = func(r tiny.Result[int, error], fn func(int) tiny.Result[int, error]) tiny.Result[int, error] {
+					return fn(r.OrElse(0))
+				}
+			},
+			law: "right identity",
+		},
+		{
+			name: "Map that adjusts the value",
+			broken: func(l *Laws[int, error]) {
+				l.MapFn = func(r tiny.Result[int, error], fn func(int) (int, error)) tiny.Result[int, error] {
+					return tiny.Map(r, func(v int) (int, error) {
+						v, err := fn(v)
+						return v + 1, err
+					})
+				}
+			},
+			law: "map identity",
+		},
+		{
+			name: "MapErr that adds context",
+			broken: func(l *Laws[int, error]) {
+				l.MapErrFn = func(r tiny.Result[int, error], fn func(error) error) tiny.Result[int, error] {
+					return tiny.MapErr(r.Wrap("again"), fn)
+				}
+			},
+			law: "map error identity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			laws := intLaws()
+			tt.broken(&laws)
+			for _, p := range laws.Properties() {
+				if p.Name == tt.law {
+					if err := quick.Check(p.Fn, nil); err == nil {
+						t.Errorf("%s should not hold", tt.law)
+					}
+					return
+				}
+			}
+			t.Fatalf("no law named %s", tt.law)
+		})
+	}
+}
+
+func TestLawsSkipMissingFunctions(t *testing.T) {
+	if got := (Laws[int, error]{}).Properties(); len(got) != 0 {
+		t.Errorf("Properties() without functions = %v, want none", got)
+	}
+}
+
+type statusError int
+
+func (e statusError) Error() string { return fmt.Sprintf("status %d", int(e)) }
+
+func TestLawsSkipMapForValueErrors(t *testing.T) {
+	laws := Laws[int, statusError]{
+		Map:    []func(int) int{func(v int) int { return v * 2 }},
+		MapErr: []func(statusError) statusError{func(e statusError) statusError { return e + 1 }},
+	}
+	if got := len(laws.Properties()); got != 2 {
+		t.Fatalf("Properties() returned %d laws, want only the 2 of MapErr", got)
+	}
+	laws.Check(t)
+}